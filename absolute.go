@@ -0,0 +1,49 @@
+package iri
+
+import "fmt"
+
+// AbsoluteIRI is an IRI that is guaranteed to have a scheme.
+//
+// The zero value is not a valid AbsoluteIRI; Use ParseAbsolute or MustParseAbsolute to create one.
+type AbsoluteIRI struct {
+	iri IRI
+}
+
+// ParseAbsolute parses a string into an AbsoluteIRI.
+// In addition to the checks of Parse, it returns an error if the IRI has no scheme.
+func ParseAbsolute(s string, opts ...ParseOption) (AbsoluteIRI, error) {
+	parsed, err := Parse(s, opts...)
+	if err != nil {
+		return AbsoluteIRI{}, err
+	}
+	if !parsed.hasScheme() {
+		return AbsoluteIRI{}, fmt.Errorf("%q is not an absolute IRI: scheme is missing", s)
+	}
+	return AbsoluteIRI{iri: parsed}, nil
+}
+
+// MustParseAbsolute is like ParseAbsolute, yet panics if the string cannot be parsed.
+// It simplifies safe initialization of global variables.
+func MustParseAbsolute(s string, opts ...ParseOption) AbsoluteIRI {
+	parsed, err := ParseAbsolute(s, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// IRI returns the absolute IRI as a plain IRI.
+func (abs AbsoluteIRI) IRI() IRI {
+	return abs.iri
+}
+
+// String returns the string of the IRI.
+func (abs AbsoluteIRI) String() string {
+	return abs.iri.String()
+}
+
+// ResolveReference resolves an IRI reference against this absolute IRI.
+// As the result inherits the scheme of the base if it has none, it is always absolute.
+func (abs AbsoluteIRI) ResolveReference(ref IRI) AbsoluteIRI {
+	return AbsoluteIRI{iri: resolveReference(abs.iri, ref)}
+}
@@ -0,0 +1,58 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestParseAbsolute(t *testing.T) {
+	tt := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: "https://example.com/path"},
+		{in: "urn:uuid:6c689097-8097-4421-9def-05e835f2dbb8"},
+		{in: "", wantErr: true},
+		{in: "//example.com/path", wantErr: true},
+		{in: "/path", wantErr: true},
+		{in: "#frag", wantErr: true},
+		{in: "https://example.com/ ", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.ParseAbsolute(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && ((got.String() != tc.in) || (got.IRI().String() != tc.in)) {
+				t.Errorf("ParseAbsolute(%q) = %q", tc.in, got)
+			}
+		})
+	}
+}
+
+func TestMustParseAbsolutePanics(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if p := recover(); p == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+	iri.MustParseAbsolute("relative")
+}
+
+func TestAbsoluteIRIResolveReference(t *testing.T) {
+	t.Parallel()
+	base := iri.MustParseAbsolute("http://a/b/c/d;p?q")
+	got := base.ResolveReference(iri.IRI{Path: "../g"})
+	if got.String() != "http://a/b/g" {
+		t.Errorf("ResolveReference() = %q, want %q", got, "http://a/b/g")
+	}
+	if got.IRI().Scheme == "" {
+		t.Errorf("resolved IRI has no scheme")
+	}
+}
@@ -0,0 +1,175 @@
+package iri
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// authorityParts holds the components of an authority as per RFC 3987, section 2.2:
+//
+//	iauthority = [ iuserinfo "@" ] ihost [ ":" port ]
+type authorityParts struct {
+	userInfo    string
+	hasUserInfo bool
+	host        string
+	port        string
+	hasPort     bool
+}
+
+// splitAuthority separates the given authority into its components.
+// The userinfo ends at the last "@", and the port starts after the last ":" that is not
+// part of a bracketed IP literal. Percent-encoded delimiters are never considered.
+func splitAuthority(authority string) authorityParts {
+	var parts authorityParts
+	hostPort := authority
+	if i := strings.LastIndexByte(authority, '@'); i >= 0 {
+		parts.userInfo, parts.hasUserInfo, hostPort = authority[:i], true, authority[i+1:]
+	}
+	parts.host = hostPort
+	if i := strings.LastIndexByte(hostPort, ':'); (i >= 0) && !strings.Contains(hostPort[i:], "]") {
+		parts.host, parts.port, parts.hasPort = hostPort[:i], hostPort[i+1:], true
+	}
+	return parts
+}
+
+// String reassembles the authority.
+func (parts authorityParts) String() string {
+	var result strings.Builder
+	if parts.hasUserInfo {
+		result.WriteString(parts.userInfo)
+		result.WriteRune('@')
+	}
+	result.WriteString(parts.host)
+	if parts.hasPort {
+		result.WriteRune(':')
+		result.WriteString(parts.port)
+	}
+	return result.String()
+}
+
+// UserInfo returns the userinfo of the authority, without the trailing "@".
+// The returned value is kept percent-encoded.
+func (iri IRI) UserInfo() string {
+	return splitAuthority(iri.Authority).userInfo
+}
+
+// Host returns the host of the authority, without userinfo and port.
+// IP literals keep their brackets. The returned value is kept percent-encoded.
+func (iri IRI) Host() string {
+	return splitAuthority(iri.Authority).host
+}
+
+// Port returns the port of the authority, without the leading ":".
+// The returned value may be empty even if the authority ends with a ":".
+func (iri IRI) Port() string {
+	return splitAuthority(iri.Authority).port
+}
+
+// PortNumber returns the port of the authority as a number.
+//
+// The returned bool is false if the port is empty or absent, in which case the number is zero.
+// An error is returned if the port is not a decimal number in the range of 0 to 65535.
+func (iri IRI) PortNumber() (uint16, bool, error) {
+	port := iri.Port()
+	if port == "" {
+		return 0, false, nil
+	}
+	number, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return 0, true, fmt.Errorf("invalid port %q: %w", port, err)
+	}
+	return uint16(number), true, nil
+}
+
+// AuthorityWithoutUserInfo returns the authority with any userinfo, including the "@", removed.
+// The result is the host, followed by the port if present; Typically used to display the server
+// without revealing any credentials.
+func (iri IRI) AuthorityWithoutUserInfo() string {
+	parts := splitAuthority(iri.Authority)
+	parts.userInfo, parts.hasUserInfo = "", false
+	return parts.String()
+}
+
+// HostType classifies the host of an authority.
+type HostType int
+
+// These are the known host types.
+const (
+	// HostTypeNone is for IRIs without a host.
+	HostTypeNone HostType = iota
+	// HostTypeRegName is for registered names, such as "example.com".
+	HostTypeRegName
+	// HostTypeIPv4 is for IPv4 addresses in dotted-decimal form, such as "192.0.2.16".
+	HostTypeIPv4
+	// HostTypeIPv6 is for bracketed IPv6 literals, such as "[2001:db8::7]", including those with
+	// a zone identifier as per RFC 6874, such as "[fe80::1%25eth0]".
+	HostTypeIPv6
+	// HostTypeIPvFuture is for bracketed literals of future IP versions, such as "[v1.fe80::a]".
+	HostTypeIPvFuture
+)
+
+// HostType returns the type of the host, as distinguished by the grammar of RFC 3987.
+//
+// A host is only of type HostTypeIPv4 if it consists of exactly four decimal octets,
+// each in the range 0-255 and without leading zeros. Any other dotted form, such as "1.2.3"
+// or "1.2.3.4.5", is a registered name.
+func (iri IRI) HostType() HostType {
+	host := iri.Host()
+	switch {
+	case host == "":
+		return HostTypeNone
+	case strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]"):
+		literal := host[1 : len(host)-1]
+		if ipVFutureRE.MatchString(literal) {
+			return HostTypeIPvFuture
+		}
+		if ipV6AddrzRE.MatchString(literal) {
+			return HostTypeIPv6
+		}
+		return HostTypeNone
+	case ipV4AddressRE.MatchString(host):
+		return HostTypeIPv4
+	default:
+		return HostTypeRegName
+	}
+}
+
+// FromHostPort creates an authority-only IRI from a "host:port" pair, such as used by net.Dial.
+//
+// The port is optional, and IPv6 addresses must be bracketed, as in "[::1]:443".
+// The returned IRI has no scheme, and its string starts with "//".
+// It returns an error if the pair contains userinfo, has an empty host or port,
+// has more than one port, or is otherwise not a valid authority.
+func FromHostPort(hostport string) (IRI, error) {
+	if strings.Contains(hostport, "@") {
+		return IRI{}, fmt.Errorf("%q is not a valid host:port pair: userinfo is not allowed", hostport)
+	}
+	parts := splitAuthority(hostport)
+	if parts.host == "" {
+		return IRI{}, fmt.Errorf("%q is not a valid host:port pair: host is missing", hostport)
+	}
+	if !strings.HasPrefix(parts.host, "[") && strings.Contains(parts.host, ":") {
+		return IRI{}, fmt.Errorf("%q is not a valid host:port pair: too many colons", hostport)
+	}
+	if parts.hasPort {
+		if _, err := strconv.ParseUint(parts.port, 10, 16); err != nil {
+			return IRI{}, fmt.Errorf("%q is not a valid host:port pair: invalid port %q", hostport, parts.port)
+		}
+	}
+	if !iauthorityRE.MatchString(hostport) {
+		return IRI{}, fmt.Errorf("%q is not a valid host:port pair: does not match regexp %s", hostport, iauthorityRE)
+	}
+	return IRI{ForceAuthority: true, Authority: hostport}, nil
+}
+
+// lowercaseHost returns the host in lowercase.
+// The zone identifier of an IPv6 literal is kept as it is, as it may be case-sensitive.
+func lowercaseHost(host string) string {
+	if strings.HasPrefix(host, "[") {
+		if i := strings.Index(host, "%25"); i >= 0 {
+			return strings.ToLower(host[:i]) + host[i:]
+		}
+	}
+	return strings.ToLower(host)
+}
@@ -0,0 +1,235 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestAuthorityAccessors(t *testing.T) {
+	tt := []struct {
+		in                           string
+		wantUserInfo                 string
+		wantHost                     string
+		wantPort                     string
+		wantAuthorityWithoutUserInfo string
+	}{
+		{in: "http:"},
+		{in: "http://"},
+		{in: "http://host", wantHost: "host", wantAuthorityWithoutUserInfo: "host"},
+		{in: "http://host:80", wantHost: "host", wantPort: "80", wantAuthorityWithoutUserInfo: "host:80"},
+		{in: "http://host:", wantHost: "host", wantAuthorityWithoutUserInfo: "host:"},
+		{in: "http://user@host", wantUserInfo: "user", wantHost: "host", wantAuthorityWithoutUserInfo: "host"},
+		{in: "http://user:pw@host:80", wantUserInfo: "user:pw", wantHost: "host", wantPort: "80", wantAuthorityWithoutUserInfo: "host:80"},
+		{in: "http://@host", wantHost: "host", wantAuthorityWithoutUserInfo: "host"},
+		{in: "http://[::1]", wantHost: "[::1]", wantAuthorityWithoutUserInfo: "[::1]"},
+		{in: "http://[::1]:443", wantHost: "[::1]", wantPort: "443", wantAuthorityWithoutUserInfo: "[::1]:443"},
+		{in: "http://user:pw@[2001:db8::1]:443", wantUserInfo: "user:pw", wantHost: "[2001:db8::1]", wantPort: "443", wantAuthorityWithoutUserInfo: "[2001:db8::1]:443"},
+		{in: "http://1.2.3.4:8080", wantHost: "1.2.3.4", wantPort: "8080", wantAuthorityWithoutUserInfo: "1.2.3.4:8080"},
+		{in: "http://µ@é.example", wantUserInfo: "µ", wantHost: "é.example", wantAuthorityWithoutUserInfo: "é.example"},
+		{in: "//h%3Aost:80", wantHost: "h%3Aost", wantPort: "80", wantAuthorityWithoutUserInfo: "h%3Aost:80"},
+		{in: "//h%3aost", wantHost: "h%3aost", wantAuthorityWithoutUserInfo: "h%3aost"},
+		{in: "//[::1]:80", wantHost: "[::1]", wantPort: "80", wantAuthorityWithoutUserInfo: "[::1]:80"},
+		{in: "//u%3Ap%40w@h%3Aost:80", wantUserInfo: "u%3Ap%40w", wantHost: "h%3Aost", wantPort: "80", wantAuthorityWithoutUserInfo: "h%3Aost:80"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.UserInfo(); got != tc.wantUserInfo {
+				t.Errorf("UserInfo() = %q, want %q", got, tc.wantUserInfo)
+			}
+			if got := value.Host(); got != tc.wantHost {
+				t.Errorf("Host() = %q, want %q", got, tc.wantHost)
+			}
+			if got := value.Port(); got != tc.wantPort {
+				t.Errorf("Port() = %q, want %q", got, tc.wantPort)
+			}
+			if got := value.AuthorityWithoutUserInfo(); got != tc.wantAuthorityWithoutUserInfo {
+				t.Errorf("AuthorityWithoutUserInfo() = %q, want %q", got, tc.wantAuthorityWithoutUserInfo)
+			}
+		})
+	}
+}
+
+func TestAuthorityWithEncodedAt(t *testing.T) {
+	t.Parallel()
+	value, err := iri.Parse("//a%40b@host")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if got := value.UserInfo(); got != "a%40b" {
+		t.Errorf("UserInfo() = %q, want %q", got, "a%40b")
+	}
+	if got := value.Host(); got != "host" {
+		t.Errorf("Host() = %q, want %q", got, "host")
+	}
+}
+
+func TestAuthorityWithUnencodedAtInUserInfo(t *testing.T) {
+	t.Parallel()
+	// The grammar of userinfo does not allow an unencoded "@", so such an authority is invalid.
+	if _, err := iri.Parse("//a@b@host"); err == nil {
+		t.Errorf("Parse() did not return an error")
+	}
+	// Manually created IRIs are still split on the last "@".
+	value := iri.IRI{Authority: "a@b@host"}
+	if got := value.UserInfo(); got != "a@b" {
+		t.Errorf("UserInfo() = %q, want %q", got, "a@b")
+	}
+	if got := value.Host(); got != "host" {
+		t.Errorf("Host() = %q, want %q", got, "host")
+	}
+}
+
+func TestHostType(t *testing.T) {
+	tt := []struct {
+		in   string
+		want iri.HostType
+	}{
+		{in: "mailto:user@example.com", want: iri.HostTypeNone},
+		{in: "file:///etc", want: iri.HostTypeNone},
+		{in: "//example.com", want: iri.HostTypeRegName},
+		{in: "//1.2.3.4", want: iri.HostTypeIPv4},
+		{in: "//1.2.3.4:80", want: iri.HostTypeIPv4},
+		{in: "//0.0.0.0", want: iri.HostTypeIPv4},
+		{in: "//255.255.255.255", want: iri.HostTypeIPv4},
+		{in: "//256.1.1.1", want: iri.HostTypeRegName},
+		{in: "//1.2.3", want: iri.HostTypeRegName},
+		{in: "//1.2.3.4.5", want: iri.HostTypeRegName},
+		{in: "//1.2.3.", want: iri.HostTypeRegName},
+		{in: "//01.2.3.4", want: iri.HostTypeRegName},
+		{in: "//1x2x3x4", want: iri.HostTypeRegName},
+		{in: "//[::1]", want: iri.HostTypeIPv6},
+		{in: "//[::1.2.3.4]:80", want: iri.HostTypeIPv6},
+		{in: "//[v1.fe80::a+en1]", want: iri.HostTypeIPvFuture},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.HostType(); got != tc.want {
+				t.Errorf("HostType() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPortNumber(t *testing.T) {
+	tt := []struct {
+		in          string
+		want        uint16
+		wantPresent bool
+		wantErr     bool
+	}{
+		{in: "http://host"},
+		{in: "http://host:"},
+		{in: "mailto:user@example.com"},
+		{in: "http://host:0", want: 0, wantPresent: true},
+		{in: "http://host:80", want: 80, wantPresent: true},
+		{in: "http://host:0080", want: 80, wantPresent: true},
+		{in: "http://[::1]:65535", want: 65535, wantPresent: true},
+		{in: "http://host:65536", wantPresent: true, wantErr: true},
+		{in: "http://host:99999999999", wantPresent: true, wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, present, err := value.PortNumber()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if (got != tc.want) || (present != tc.wantPresent) {
+				t.Errorf("PortNumber() = (%d, %v), want (%d, %v)", got, present, tc.want, tc.wantPresent)
+			}
+		})
+	}
+}
+
+func TestFromHostPort(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "host:80", want: "//host:80"},
+		{in: "[::1]:443", want: "//[::1]:443"},
+		{in: "[::1]", want: "//[::1]"},
+		{in: "host", want: "//host"},
+		{in: "192.0.2.16:8080", want: "//192.0.2.16:8080"},
+		{in: "bücher.example:80", want: "//bücher.example:80"},
+		{in: "", wantErr: true},
+		{in: ":80", wantErr: true},
+		{in: "host:", wantErr: true},
+		{in: "host:port", wantErr: true},
+		{in: "host:80:extra", wantErr: true},
+		{in: "host:65536", wantErr: true},
+		{in: "::1", wantErr: true},
+		{in: "[::1]:443:1", wantErr: true},
+		{in: "[::1", wantErr: true},
+		{in: "user@host:80", wantErr: true},
+		{in: "host/path", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.FromHostPort(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("FromHostPort(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+			if !tc.wantErr && (got.Authority != tc.in) {
+				t.Errorf("FromHostPort(%q) has authority %q", tc.in, got.Authority)
+			}
+		})
+	}
+}
+
+func TestParseUnderscoreInHost(t *testing.T) {
+	tt := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: "//my_host.example"},
+		{in: "http://_srv._tcp.example/"},
+		{in: "http://my_host.example:8080/a_b"},
+		{in: "//my host.example", wantErr: true},
+		{in: "//my|host.example", wantErr: true},
+		{in: "//my^host.example", wantErr: true},
+		{in: "//my\\host.example", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if (err == nil) && (got.HostType() != iri.HostTypeRegName) {
+				t.Errorf("HostType() = %v, want %v", got.HostType(), iri.HostTypeRegName)
+			}
+		})
+	}
+}
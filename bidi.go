@@ -0,0 +1,113 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BidiIssue describes a violation of the rules for bidirectional IRIs in a component of an IRI.
+//
+// See https://www.ietf.org/rfc/rfc3987.html#section-4.
+type BidiIssue struct {
+	// Component is the component that contains the violation.
+	Component Component
+	// Start is the byte offset of the offending character range within the component.
+	Start int
+	// End is the byte offset after the offending character range within the component.
+	End int
+	// Reason describes which rule is violated.
+	Reason string
+}
+
+// String returns a description of the issue.
+func (issue BidiIssue) String() string {
+	return fmt.Sprintf("%s at offset %d to %d: %s", issue.Component, issue.Start, issue.End, issue.Reason)
+}
+
+// BidiIssues reports all violations of the rules for bidirectional IRIs of RFC 3987, Section 4.
+// It returns nil if there are none.
+//
+// The rules are checked for the parts of each component between delimiters, such as host labels
+// and path segments. Such a part must not contain bidirectional formatting characters, must not mix
+// right-to-left and left-to-right characters, and, if it contains right-to-left characters,
+// must start and end with one. Percent-encoded characters are not decoded.
+//
+// Parsing does not enforce these rules, as RFC 3987 only recommends them for most cases.
+// This method allows to warn about IRIs that may be displayed in a misleading way.
+func (iri IRI) BidiIssues() []BidiIssue {
+	var issues []BidiIssue
+	issues = append(issues, bidiIssues(ComponentAuthority, iri.Authority)...)
+	issues = append(issues, bidiIssues(ComponentPath, iri.Path)...)
+	issues = append(issues, bidiIssues(ComponentQuery, iri.Query)...)
+	issues = append(issues, bidiIssues(ComponentFragment, iri.Fragment)...)
+	return issues
+}
+
+// bidiDelimiters separate the parts of a component to which the rules are applied individually.
+const bidiDelimiters = ":/?#[]@!$&'()*+,;=."
+
+func bidiIssues(component Component, s string) []BidiIssue {
+	var issues []BidiIssue
+	start := 0
+	for start <= len(s) {
+		end := strings.IndexAny(s[start:], bidiDelimiters)
+		if end < 0 {
+			end = len(s)
+		} else {
+			end += start
+		}
+		issues = append(issues, bidiPartIssues(component, s, start, end)...)
+		start = end + 1
+	}
+	return issues
+}
+
+func bidiPartIssues(component Component, s string, start, end int) []BidiIssue {
+	var issues []BidiIssue
+	hasRTL, hasLTR := false, false
+	for offset, r := range s[start:end] {
+		switch {
+		case isBidiFormatting(r):
+			issues = append(issues, BidiIssue{Component: component, Start: start + offset, End: start + offset + utf8.RuneLen(r), Reason: "bidirectional formatting character"})
+		case isRightToLeft(r):
+			hasRTL = true
+		case unicode.IsLetter(r):
+			hasLTR = true
+		}
+	}
+	if !hasRTL {
+		return issues
+	}
+	if hasLTR {
+		issues = append(issues, BidiIssue{Component: component, Start: start, End: end, Reason: "mixes right-to-left and left-to-right characters"})
+	}
+	first, _ := utf8.DecodeRuneInString(s[start:end])
+	last, _ := utf8.DecodeLastRuneInString(s[start:end])
+	if !isRightToLeft(first) || !isRightToLeft(last) {
+		issues = append(issues, BidiIssue{Component: component, Start: start, End: end, Reason: "does not start and end with a right-to-left character"})
+	}
+	return issues
+}
+
+// isBidiFormatting returns true for the bidirectional formatting characters that RFC 3987 prohibits:
+// LRM, RLM, LRE, RLE, PDF, LRO, and RLO.
+func isBidiFormatting(r rune) bool {
+	return (r == '\u200E') || (r == '\u200F') || (('\u202A' <= r) && (r <= '\u202E'))
+}
+
+// rightToLeftScripts approximate the characters with a strong right-to-left direction.
+var rightToLeftScripts = []*unicode.RangeTable{
+	unicode.Arabic,
+	unicode.Hebrew,
+	unicode.Mandaic,
+	unicode.Nko,
+	unicode.Samaritan,
+	unicode.Syriac,
+	unicode.Thaana,
+}
+
+func isRightToLeft(r rune) bool {
+	return unicode.IsOneOf(rightToLeftScripts, r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+}
@@ -0,0 +1,76 @@
+package iri_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestBidiIssues(t *testing.T) {
+	tt := []struct {
+		name string
+		in   string
+		want []iri.BidiIssue
+	}{
+		{
+			name: "left-to-right only",
+			in:   "http://example.com/path?q#frag",
+			want: nil,
+		},
+		{
+			name: "right-to-left host label and path segment",
+			in:   "http://אבג.example.com/العربية/x",
+			want: nil,
+		},
+		{
+			name: "right-to-left fragment starts with left-to-right digit",
+			in:   "http://example.com/#1אב",
+			want: []iri.BidiIssue{
+				{Component: iri.ComponentFragment, Start: 0, End: 5, Reason: "does not start and end with a right-to-left character"},
+			},
+		},
+		{
+			name: "mixed directions in path segment",
+			in:   "http://example.com/a/bא/c",
+			want: []iri.BidiIssue{
+				{Component: iri.ComponentPath, Start: 3, End: 6, Reason: "mixes right-to-left and left-to-right characters"},
+				{Component: iri.ComponentPath, Start: 3, End: 6, Reason: "does not start and end with a right-to-left character"},
+			},
+		},
+		{
+			name: "formatting character in query",
+			in:   "http://example.com/?a=\u202Eb",
+			want: []iri.BidiIssue{
+				{Component: iri.ComponentQuery, Start: 2, End: 5, Reason: "bidirectional formatting character"},
+			},
+		},
+		{
+			name: "right-to-left digits in the middle are allowed",
+			in:   "http://example.com/#א١ב",
+			want: nil,
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.BidiIssues(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("BidiIssues() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBidiIssueString(t *testing.T) {
+	t.Parallel()
+	issue := iri.BidiIssue{Component: iri.ComponentFragment, Start: 0, End: 5, Reason: "some reason"}
+	if got, want := issue.String(), "fragment at offset 0 to 5: some reason"; got != want {
+		t.Errorf("String() got %q, want %q", got, want)
+	}
+}
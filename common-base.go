@@ -0,0 +1,44 @@
+package iri
+
+import "strings"
+
+// CommonBase returns the deepest IRI that is a base of all given IRIs.
+//
+// The returned IRI consists of the shared scheme and authority, and the longest
+// common path prefix that ends with a slash ('/'). Query and fragment are never part of it.
+// If no IRIs are given, or if they do not all have the same scheme and authority,
+// this function returns false and an empty IRI.
+// Components are compared with simple string comparison; Normalize the IRIs beforehand
+// should encoding or case differences be ignored.
+func CommonBase(iris ...IRI) (IRI, bool) {
+	if len(iris) == 0 || !iris[0].hasScheme() {
+		return IRI{}, false
+	}
+	first := iris[0]
+	prefix := directoryOf(first.Path)
+	for _, other := range iris[1:] {
+		if (other.Scheme != first.Scheme) || (other.Authority != first.Authority) || (other.hasAuthority() != first.hasAuthority()) {
+			return IRI{}, false
+		}
+		prefix = commonPrefix(prefix, directoryOf(other.Path))
+	}
+	return IRI{
+		Scheme:         first.Scheme,
+		ForceAuthority: first.ForceAuthority,
+		Authority:      first.Authority,
+		Path:           directoryOf(prefix),
+	}, true
+}
+
+// directoryOf returns the path up to and including the last slash.
+func directoryOf(path string) string {
+	return path[:strings.LastIndexByte(path, '/')+1]
+}
+
+func commonPrefix(a, b string) string {
+	i := 0
+	for (i < len(a)) && (i < len(b)) && (a[i] == b[i]) {
+		i++
+	}
+	return a[:i]
+}
@@ -0,0 +1,93 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestCommonBase(t *testing.T) {
+	tt := []struct {
+		name   string
+		in     []string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "shared directory",
+			in:     []string{"https://a/b/c", "https://a/b/d/e?q=1", "https://a/b/#frag"},
+			want:   "https://a/b/",
+			wantOk: true,
+		},
+		{
+			name:   "partial segments are not shared",
+			in:     []string{"https://a/b/cd/x", "https://a/b/ce/y"},
+			want:   "https://a/b/",
+			wantOk: true,
+		},
+		{
+			name:   "single IRI",
+			in:     []string{"https://a/b/c/d"},
+			want:   "https://a/b/c/",
+			wantOk: true,
+		},
+		{
+			name:   "only authority shared",
+			in:     []string{"https://a/b/c", "https://a", "https://a/b/d"},
+			want:   "https://a",
+			wantOk: true,
+		},
+		{
+			name:   "empty authority",
+			in:     []string{"file:///a/b", "file:///a/c"},
+			want:   "file:///a/",
+			wantOk: true,
+		},
+		{
+			name:   "diverging authority",
+			in:     []string{"https://a/b/c", "https://a/b/d", "https://x/b/c"},
+			wantOk: false,
+		},
+		{
+			name:   "diverging scheme",
+			in:     []string{"https://a/b/c", "http://a/b/c"},
+			wantOk: false,
+		},
+		{
+			name:   "relative references",
+			in:     []string{"b/c", "b/d"},
+			wantOk: false,
+		},
+		{
+			name:   "none",
+			in:     nil,
+			wantOk: false,
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var values []iri.IRI
+			for _, s := range tc.in {
+				value, err := iri.Parse(s)
+				if err != nil {
+					t.Fatalf("IRI %q is not a valid IRI: %v", s, err)
+				}
+				values = append(values, value)
+			}
+			want, err := iri.Parse(tc.want)
+			if err != nil {
+				t.Fatalf("IRI %q is not a valid IRI: %v", tc.want, err)
+			}
+			got, ok := iri.CommonBase(values...)
+			if ok != tc.wantOk {
+				t.Errorf("CommonBase() ok = %v, want %v", ok, tc.wantOk)
+			}
+			if got != want {
+				t.Errorf("CommonBase() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
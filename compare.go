@@ -0,0 +1,65 @@
+package iri
+
+import "strings"
+
+// Equal returns true if both IRIs have the same string.
+//
+// This is the simple string comparison of RFC 3987, without any normalization.
+// It is possible for two different IRI values to be equal, if they differ only in
+// Force* flags that have no effect on their string.
+// See https://www.ietf.org/rfc/rfc3987.html#section-5.3.1.
+func Equal(a, b IRI) bool {
+	return a.String() == b.String()
+}
+
+// EqualIgnoringFragment returns true if both IRIs are equal as per Equal, not considering their fragments.
+//
+// Two IRIs that differ only in their fragment identify the same resource, for example
+// for the purpose of HTTP caching. An empty query is still different from an absent query.
+func EqualIgnoringFragment(a, b IRI) bool {
+	return Equal(a.withoutFragment(), b.withoutFragment())
+}
+
+// EquivalentBase returns true if both IRIs resolve all references identically when used as base.
+//
+// The fragment of a base is never part of a resolved reference, so the IRIs are compared
+// by scheme, authority, path, and query only, as per EqualIgnoringFragment. This includes opaque
+// bases, as resolving a path against them drops the fragment of the base, see IRI.ResolveReference.
+// The path is compared as it is, without removing dot segments.
+func EquivalentBase(a, b IRI) bool {
+	return EqualIgnoringFragment(a, b)
+}
+
+// EqualIgnoringFragmentCase returns true if both IRIs are equal as per EqualIgnoringFragment,
+// and their fragments are equal under Unicode case folding, such as "#Section1" and "#section1".
+// A present, yet empty fragment is still different from an absent fragment.
+//
+// RFC 3987 compares fragments case-sensitively. This comparison is meant for applications
+// that treat their anchors case-insensitively. Percent-encoded octets are not decoded.
+func EqualIgnoringFragmentCase(a, b IRI) bool {
+	return EqualIgnoringFragment(a, b) && (a.hasFragment() == b.hasFragment()) && strings.EqualFold(a.Fragment, b.Fragment)
+}
+
+func (iri IRI) withoutFragment() IRI {
+	result := iri
+	result.ForceFragment, result.Fragment = false, ""
+	return result
+}
+
+// PathEqual returns true if both IRIs have equivalent paths, ignoring all other components.
+// This is useful to check whether two references address the same route.
+//
+// The paths are compared after percent-encoding normalization and, for paths that begin
+// with a slash, removal of dot segments. Percent-encoded reserved characters stay encoded,
+// so "/a%2Fb" and "/a/b" are different paths. Paths with invalid percent-encoding are
+// compared without percent-encoding normalization.
+func PathEqual(a, b IRI) bool {
+	return comparablePath(a.Path) == comparablePath(b.Path)
+}
+
+func comparablePath(path string) string {
+	if decoded, err := normalizePercentEncoding(path, isIUnreserved, nil); err == nil {
+		path = decoded
+	}
+	return removeHierarchicalDotSegments(uppercasePercentHex(path))
+}
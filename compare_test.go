@@ -0,0 +1,195 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestEqual(t *testing.T) {
+	tt := []struct {
+		a, b iri.IRI
+		want bool
+	}{
+		{a: iri.IRI{}, b: iri.IRI{}, want: true},
+		{a: iri.IRI{Query: "q"}, b: iri.IRI{Query: "q", ForceQuery: true}, want: true},
+		{a: iri.IRI{Path: "/a"}, b: iri.IRI{Path: "/a", ForceQuery: true}, want: false},
+		{a: iri.IRI{Path: "/%C2%B5"}, b: iri.IRI{Path: "/µ"}, want: false},
+		{a: iri.IRI{Scheme: "http"}, b: iri.IRI{Scheme: "HTTP"}, want: false},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.a.String()+" "+tc.b.String(), func(t *testing.T) {
+			t.Parallel()
+			if got := iri.Equal(tc.a, tc.b); got != tc.want {
+				t.Errorf("Equal(%#v, %#v) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEqualIgnoringFragment(t *testing.T) {
+	tt := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "http://a/b", b: "http://a/b", want: true},
+		{a: "http://a/b#f1", b: "http://a/b#f2", want: true},
+		{a: "http://a/b#f1", b: "http://a/b", want: true},
+		{a: "http://a/b#", b: "http://a/b", want: true},
+		{a: "http://a/b?#", b: "http://a/b?", want: true},
+		{a: "http://a/b?#f", b: "http://a/b#f", want: false},
+		{a: "http://a/b?q#f", b: "http://a/b?r#f", want: false},
+		{a: "http://a/b#f", b: "http://a/c#f", want: false},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			t.Parallel()
+			a, errA := iri.Parse(tc.a)
+			b, errB := iri.Parse(tc.b)
+			if (errA != nil) || (errB != nil) {
+				t.Fatalf("Parse() returned errors: %v, %v", errA, errB)
+			}
+			if got := iri.EqualIgnoringFragment(a, b); got != tc.want {
+				t.Errorf("EqualIgnoringFragment() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEquivalentBase(t *testing.T) {
+	tt := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "http://a/b/c/d;p?q#f1", b: "http://a/b/c/d;p?q#f2", want: true},
+		{a: "http://a/b/c/d;p?q#", b: "http://a/b/c/d;p?q", want: true},
+		{a: "http://a/b/c/d;p?q", b: "http://a/b/c/d;p?r", want: false},
+		{a: "http://a/b/c/d;p", b: "http://a/b/c/d;p?", want: false},
+		{a: "http://a/b/c/d;p", b: "http://a/b/c/e", want: false},
+		{a: "http://a/b", b: "https://a/b", want: false},
+		{a: "urn:a:b#x", b: "urn:a:b#y", want: true},
+		{a: "urn:a:b?q#x", b: "urn:a:b?q", want: true},
+		{a: "urn:a:b", b: "urn:a:c", want: false},
+	}
+	refs := []string{"", "#s", "?y", "g", "../g", "/g", "//g", "g:h", "baz?q#f"}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			t.Parallel()
+			a, errA := iri.Parse(tc.a)
+			b, errB := iri.Parse(tc.b)
+			if (errA != nil) || (errB != nil) {
+				t.Fatalf("Parse() returned errors: %v, %v", errA, errB)
+			}
+			if got := iri.EquivalentBase(a, b); got != tc.want {
+				t.Errorf("EquivalentBase() = %v, want %v", got, tc.want)
+			}
+			if !tc.want {
+				return
+			}
+			for _, rawRef := range refs {
+				ref, err := iri.Parse(rawRef)
+				if err != nil {
+					t.Fatalf("Parse() returned error: %v", err)
+				}
+				if resolvedA, resolvedB := a.ResolveReference(ref), b.ResolveReference(ref); resolvedA != resolvedB {
+					t.Errorf("equivalent bases resolve %q differently: %q, %q", rawRef, resolvedA, resolvedB)
+				}
+			}
+		})
+	}
+}
+
+func TestPathEqual(t *testing.T) {
+	tt := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "/a/b", b: "/a/b", want: true},
+		{a: "/a/b", b: "/a/c", want: false},
+		{a: "/a%2Fb", b: "/a/b", want: false},
+		{a: "/a%2fb", b: "/a%2Fb", want: true},
+		{a: "/a/./b/../c", b: "/a/c", want: true},
+		{a: "/a/%2E%2E/c", b: "/c", want: true},
+		{a: "/%7Euser", b: "/~user", want: true},
+		{a: "/%C2%B5", b: "/µ", want: true},
+		{a: "/a/", b: "/a", want: false},
+		{a: "http://example.com/a?q#f", b: "https://other.example/a?r", want: true},
+		{a: "urn:a:b", b: "mailto:a:b", want: true},
+		{a: "a/../b", b: "b", want: false},
+		{a: "", b: "/", want: false},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			t.Parallel()
+			a, err := iri.Parse(tc.a)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			b, err := iri.Parse(tc.b)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := iri.PathEqual(a, b); got != tc.want {
+				t.Errorf("PathEqual(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+			if got := iri.PathEqual(b, a); got != tc.want {
+				t.Errorf("PathEqual(%q, %q) = %v, want %v", tc.b, tc.a, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPathEqualWithInvalidPercentEncoding(t *testing.T) {
+	t.Parallel()
+	a := iri.IRI{Path: "/%ff/./a"}
+	b := iri.IRI{Path: "/%FF/a"}
+	if !iri.PathEqual(a, b) {
+		t.Errorf("PathEqual(%q, %q) = false, want true", a, b)
+	}
+}
+
+func TestEqualIgnoringFragmentCase(t *testing.T) {
+	tt := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "https://example.com/doc#Section1", b: "https://example.com/doc#section1", want: true},
+		{a: "https://example.com/doc#SECTION1", b: "https://example.com/doc#Section1", want: true},
+		{a: "https://example.com/doc#Straße", b: "https://example.com/doc#STRAßE", want: true},
+		{a: "https://example.com/doc#Ärger", b: "https://example.com/doc#ärger", want: true},
+		{a: "https://example.com/doc#section1", b: "https://example.com/doc#section2", want: false},
+		{a: "https://example.com/Doc#section1", b: "https://example.com/doc#section1", want: false},
+		{a: "https://example.com/doc?Q#a", b: "https://example.com/doc?q#a", want: false},
+		{a: "https://example.com/doc#", b: "https://example.com/doc", want: false},
+		{a: "https://example.com/doc", b: "https://example.com/doc", want: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			t.Parallel()
+			a, err := iri.Parse(tc.a)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			b, err := iri.Parse(tc.b)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := iri.EqualIgnoringFragmentCase(a, b); got != tc.want {
+				t.Errorf("EqualIgnoringFragmentCase(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+			if got := iri.EqualIgnoringFragmentCase(b, a); got != tc.want {
+				t.Errorf("EqualIgnoringFragmentCase(%q, %q) = %v, want %v", tc.b, tc.a, got, tc.want)
+			}
+		})
+	}
+}
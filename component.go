@@ -0,0 +1,63 @@
+package iri
+
+// Component identifies one of the components of an IRI.
+type Component int
+
+// These are the components of an IRI, in the order they appear in its string.
+const (
+	ComponentScheme Component = iota
+	ComponentAuthority
+	ComponentPath
+	ComponentQuery
+	ComponentFragment
+)
+
+// String returns the lowercase name of the component, as used in RFC 3987.
+func (c Component) String() string {
+	switch c {
+	case ComponentScheme:
+		return "scheme"
+	case ComponentAuthority:
+		return "authority"
+	case ComponentPath:
+		return "path"
+	case ComponentQuery:
+		return "query"
+	case ComponentFragment:
+		return "fragment"
+	default:
+		return "unknown"
+	}
+}
+
+// components lists all components in the order they appear in the string of an IRI.
+var components = []Component{ComponentScheme, ComponentAuthority, ComponentPath, ComponentQuery, ComponentFragment}
+
+// Fields returns the values of all components, keyed by component. Components that are
+// absent have an empty value; Use Forced to distinguish them from forced empty components.
+//
+// Unlike the fields of the IRI type, this does not depend on the layout of the type.
+func (iri IRI) Fields() map[Component]string {
+	return map[Component]string{
+		ComponentScheme:    iri.Scheme,
+		ComponentAuthority: iri.Authority,
+		ComponentPath:      iri.Path,
+		ComponentQuery:     iri.Query,
+		ComponentFragment:  iri.Fragment,
+	}
+}
+
+// Forced returns the Force* flag of the given component. It is always false for
+// the scheme and the path, as these components have no such flag.
+func (iri IRI) Forced(c Component) bool {
+	switch c {
+	case ComponentAuthority:
+		return iri.ForceAuthority
+	case ComponentQuery:
+		return iri.ForceQuery
+	case ComponentFragment:
+		return iri.ForceFragment
+	default:
+		return false
+	}
+}
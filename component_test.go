@@ -0,0 +1,71 @@
+package iri_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestFields(t *testing.T) {
+	tt := []struct {
+		in         string
+		want       map[iri.Component]string
+		wantForced []iri.Component
+	}{
+		{
+			in: "https://user@example.com:8080/a/b?q=1#f",
+			want: map[iri.Component]string{
+				iri.ComponentScheme:    "https",
+				iri.ComponentAuthority: "user@example.com:8080",
+				iri.ComponentPath:      "/a/b",
+				iri.ComponentQuery:     "q=1",
+				iri.ComponentFragment:  "f",
+			},
+		},
+		{
+			in: "file:///etc/hosts?#",
+			want: map[iri.Component]string{
+				iri.ComponentScheme:    "file",
+				iri.ComponentAuthority: "",
+				iri.ComponentPath:      "/etc/hosts",
+				iri.ComponentQuery:     "",
+				iri.ComponentFragment:  "",
+			},
+			wantForced: []iri.Component{iri.ComponentAuthority, iri.ComponentQuery, iri.ComponentFragment},
+		},
+		{
+			in: "",
+			want: map[iri.Component]string{
+				iri.ComponentScheme:    "",
+				iri.ComponentAuthority: "",
+				iri.ComponentPath:      "",
+				iri.ComponentQuery:     "",
+				iri.ComponentFragment:  "",
+			},
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.Fields(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Fields() = %v, want %v", got, tc.want)
+			}
+			var forced []iri.Component
+			for _, component := range []iri.Component{iri.ComponentScheme, iri.ComponentAuthority, iri.ComponentPath, iri.ComponentQuery, iri.ComponentFragment} {
+				if value.Forced(component) {
+					forced = append(forced, component)
+				}
+			}
+			if !reflect.DeepEqual(forced, tc.wantForced) {
+				t.Errorf("Forced() is true for %v, want %v", forced, tc.wantForced)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package iri_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+// TestConcurrentUse calls the functions of the package with shared inputs from many goroutines.
+// Run it with the race detector to verify that the package is safe for concurrent use.
+func TestConcurrentUse(t *testing.T) {
+	t.Parallel()
+	inputs := []string{
+		"https://User@Bücher.example:443/a/./b/../%7e%c2%b5?q=%20&b=2&a=1#frag",
+		"mailto:a@example.com,b@example.com?subject=Hello%20there",
+		"tel:7042;phone-context=example.com",
+		"urn:uuid:6c689097-8097-4421-9def-05e835f2dbb8",
+		"//[::1]:80/x",
+		"../g;x?y#s",
+	}
+	values := make([]iri.IRI, len(inputs))
+	for i, in := range inputs {
+		value, err := iri.Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", in, err)
+		}
+		values[i] = value
+	}
+	base := values[0]
+	var interner iri.Interner
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i, value := range values {
+				_, _ = iri.Parse(inputs[i], iri.LowercaseScheme(), iri.MaxPathSegments(10))
+				_, _ = value.Normalize()
+				_ = value.CanonicalString()
+				_, _ = iri.NormalizePercentEncoding(value)
+				_, _, _ = iri.NormalizePercentEncodingWithStats(value)
+				_ = iri.UppercasePercentHex(value)
+				_ = value.NormalizeHost()
+				_ = base.ResolveReference(value)
+				_ = base.ResolveAll(values)
+				_, _ = iri.EqualUnderProfile(base, value)
+				_ = iri.EqualNormalized(base, value)
+				_ = iri.Diff(base, value)
+				_ = value.Sum64()
+				_, _ = value.ToURIHostOnly()
+				_, _ = value.HostConfusableScripts()
+				_ = value.BidiIssues()
+				_ = value.SortQuery()
+				_, _ = value.QueryValues()
+				_, _, _, _ = value.URN()
+				_, _, _ = value.MailtoParts()
+				_, _, _ = value.TelParts()
+				_ = value.Validate()
+				_ = iri.ValidatePercentEncoding(value)
+				_ = interner.Intern(value)
+				_ = iri.FindAll(inputs[i])
+				_, _ = iri.Pattern("path")
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, value := range values {
+		if value.String() != inputs[i] {
+			t.Errorf("shared IRI was modified: got %q, want %q", value, inputs[i])
+		}
+	}
+}
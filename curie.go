@@ -0,0 +1,55 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToCURIE abbreviates the IRI as a compact URI (CURIE), such as "foaf:name", with the given map
+// of prefixes to namespace IRIs, such as "foaf" to "http://xmlns.com/foaf/0.1/".
+//
+// The namespace that is the longest prefix of the string of the IRI is used. If several prefixes
+// map to that namespace, the prefix that sorts first is used. Empty namespaces are ignored.
+// It returns false if no namespace matches.
+//
+// See https://www.w3.org/TR/curie/.
+func ToCURIE(iri IRI, prefixes map[string]string) (string, bool) {
+	s := iri.String()
+	var bestPrefix, bestNamespace string
+	found := false
+	for prefix, namespace := range prefixes {
+		if (namespace == "") || !strings.HasPrefix(s, namespace) {
+			continue
+		}
+		longer := len(namespace) > len(bestNamespace)
+		if !found || longer || ((len(namespace) == len(bestNamespace)) && (prefix < bestPrefix)) {
+			bestPrefix, bestNamespace, found = prefix, namespace, true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	return bestPrefix + ":" + s[len(bestNamespace):], true
+}
+
+// FromCURIE expands a compact URI (CURIE), such as "foaf:name", into an IRI with the given map
+// of prefixes to namespace IRIs. A safe CURIE, enclosed in square brackets as in "[foaf:name]",
+// is accepted as well.
+//
+// It returns an error if the CURIE has no prefix, if the prefix is not in the map,
+// or if the expanded string is not a valid IRI.
+func FromCURIE(curie string, prefixes map[string]string) (IRI, error) {
+	unwrapped := curie
+	if strings.HasPrefix(curie, "[") && strings.HasSuffix(curie, "]") {
+		unwrapped = curie[1 : len(curie)-1]
+	}
+	prefix, reference, found := strings.Cut(unwrapped, ":")
+	if !found {
+		return IRI{}, fmt.Errorf("%q is not a valid CURIE: prefix is missing", curie)
+	}
+	namespace, known := prefixes[prefix]
+	if !known {
+		return IRI{}, fmt.Errorf("%q is not a valid CURIE: unknown prefix %q", curie, prefix)
+	}
+	return Parse(namespace + reference)
+}
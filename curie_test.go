@@ -0,0 +1,89 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+var curiePrefixes = map[string]string{
+	"foaf":   "http://xmlns.com/foaf/0.1/",
+	"rdf":    "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"rdfs":   "http://www.w3.org/2000/01/rdf-schema#",
+	"ex":     "http://example.com/",
+	"exdocs": "http://example.com/docs/",
+	"alias":  "http://example.com/docs/",
+	"empty":  "",
+}
+
+func TestToCURIE(t *testing.T) {
+	tt := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{in: "http://xmlns.com/foaf/0.1/name", want: "foaf:name", wantOK: true},
+		{in: "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", want: "rdf:type", wantOK: true},
+		{in: "http://www.w3.org/2000/01/rdf-schema#label", want: "rdfs:label", wantOK: true},
+		{in: "http://example.com/docs/intro", want: "alias:intro", wantOK: true},
+		{in: "http://example.com/other", want: "ex:other", wantOK: true},
+		{in: "http://example.com/", want: "ex:", wantOK: true},
+		{in: "https://example.com/other"},
+		{in: "urn:isbn:0451450523"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, ok := iri.ToCURIE(value, curiePrefixes)
+			if (got != tc.want) || (ok != tc.wantOK) {
+				t.Errorf("ToCURIE(%q) = %q, %v, want %q, %v", tc.in, got, ok, tc.want, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			back, err := iri.FromCURIE(got, curiePrefixes)
+			if err != nil {
+				t.Fatalf("FromCURIE(%q) returned error: %v", got, err)
+			}
+			if back != value {
+				t.Errorf("FromCURIE(%q) = %q, want %q", got, back, value)
+			}
+		})
+	}
+}
+
+func TestFromCURIE(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "foaf:name", want: "http://xmlns.com/foaf/0.1/name"},
+		{in: "[foaf:name]", want: "http://xmlns.com/foaf/0.1/name"},
+		{in: "ex:a/b?c#d", want: "http://example.com/a/b?c#d"},
+		{in: "ex:a:b", want: "http://example.com/a:b"},
+		{in: "name", wantErr: true},
+		{in: "dc:title", wantErr: true},
+		{in: "ex:a b", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.FromCURIE(tc.in, curiePrefixes)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("FromCURIE(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
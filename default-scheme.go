@@ -0,0 +1,48 @@
+package iri
+
+import "strings"
+
+// ParseWithDefaultScheme parses a string like Parse, yet prepends the default scheme and "//"
+// if the string looks like it starts with a host, such as "example.com/path".
+//
+// This is a heuristic for user input, as browsers apply it, and not part of RFC 3987.
+// A string is considered to start with a host if it does not start with a slash ('/') or a dot ('.'),
+// and if, before the first slash, question mark, or number sign, it either is a host and port such as
+// "localhost:8080", or has no scheme and contains a dot.
+// All other strings are parsed as they are; For example, "/relative" stays a relative reference.
+// Note that a colon followed by digits only is always taken as port, even though the part before the colon
+// may be a valid scheme; For example, "example.com:8080/path" and "tel:110" both get the default scheme.
+func ParseWithDefaultScheme(s, defaultScheme string, opts ...ParseOption) (IRI, error) {
+	if looksLikeHost(s) {
+		return Parse(defaultScheme+"://"+s, opts...)
+	}
+	return Parse(s, opts...)
+}
+
+func looksLikeHost(s string) bool {
+	if strings.HasPrefix(s, "/") || strings.HasPrefix(s, ".") {
+		return false
+	}
+	candidate := s
+	if i := strings.IndexAny(s, "/?#"); i >= 0 {
+		candidate = s[:i]
+	}
+	if before, port, found := strings.Cut(candidate, ":"); found {
+		if (before != "") && isDigits(port) {
+			return true
+		}
+		if schemeRE.MatchString(before) {
+			return false
+		}
+	}
+	return strings.Contains(candidate, ".")
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < '0') || (s[i] > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
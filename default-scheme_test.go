@@ -0,0 +1,52 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestParseWithDefaultScheme(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "example.com/x", want: "https://example.com/x"},
+		{in: "example.com", want: "https://example.com"},
+		{in: "www.example.com?q=1#f", want: "https://www.example.com?q=1#f"},
+		{in: "user@bücher.example/µ", want: "https://user@bücher.example/µ"},
+		{in: "192.0.2.16/x", want: "https://192.0.2.16/x"},
+		{in: "http://example.com/x", want: "http://example.com/x"},
+		{in: "mailto:user@example.com", want: "mailto:user@example.com"},
+		{in: "/relative", want: "/relative"},
+		{in: "relative/path.html", want: "relative/path.html"},
+		{in: "./file.txt", want: "./file.txt"},
+		{in: "../file.txt", want: "../file.txt"},
+		{in: "//example.com/x", want: "//example.com/x"},
+		{in: "?q=a.b", want: "?q=a.b"},
+		{in: "localhost/x", want: "localhost/x"},
+		{in: "example.com:8080/x", want: "https://example.com:8080/x"},
+		{in: "localhost:8080", want: "https://localhost:8080"},
+		{in: "localhost:8080?q#f", want: "https://localhost:8080?q#f"},
+		{in: "user@localhost:8080/x", want: "https://user@localhost:8080/x"},
+		{in: "example.com:80a/x", want: "example.com:80a/x"},
+		{in: "urn:isbn:0451450523", want: "urn:isbn:0451450523"},
+		{in: ":8080", wantErr: true},
+		{in: "example.com/a b", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.ParseWithDefaultScheme(tc.in, "https")
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("ParseWithDefaultScheme(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ComponentDiff describes how a component differs between two IRIs.
+type ComponentDiff struct {
+	// Component is the component that differs.
+	Component Component
+	// Old is the value of the component in the first IRI.
+	Old string
+	// OldForced is the Force* flag of the component in the first IRI. It is always false for the scheme and the path.
+	OldForced bool
+	// New is the value of the component in the second IRI.
+	New string
+	// NewForced is the Force* flag of the component in the second IRI. It is always false for the scheme and the path.
+	NewForced bool
+}
+
+// String returns a description of the difference, such as `path: "/a" -> "/b"`.
+// A forced component is marked with "(forced)".
+func (diff ComponentDiff) String() string {
+	return fmt.Sprintf("%s: %s -> %s", diff.Component, quoteForced(diff.Old, diff.OldForced), quoteForced(diff.New, diff.NewForced))
+}
+
+func quoteForced(value string, forced bool) string {
+	if forced {
+		return fmt.Sprintf("%q (forced)", value)
+	}
+	return fmt.Sprintf("%q", value)
+}
+
+// ComponentDiffs is a list of differences between two IRIs, as returned by Diff.
+type ComponentDiffs []ComponentDiff
+
+// String returns the descriptions of all differences, one per line.
+func (diffs ComponentDiffs) String() string {
+	lines := make([]string, len(diffs))
+	for i, diff := range diffs {
+		lines[i] = diff.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff returns the components that differ between the two IRIs, in the order they appear in an IRI string.
+// A component differs if either its value or its Force* flag differs. The IRIs are compared as they are,
+// without normalization. Diff returns nil if the IRIs are identical.
+func Diff(a, b IRI) ComponentDiffs {
+	var diffs ComponentDiffs
+	oldFields, newFields := a.Fields(), b.Fields()
+	for _, component := range components {
+		oldValue, oldForced := oldFields[component], a.Forced(component)
+		newValue, newForced := newFields[component], b.Forced(component)
+		if (oldValue != newValue) || (oldForced != newForced) {
+			diffs = append(diffs, ComponentDiff{Component: component, Old: oldValue, OldForced: oldForced, New: newValue, NewForced: newForced})
+		}
+	}
+	return diffs
+}
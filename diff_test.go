@@ -0,0 +1,69 @@
+package iri_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestDiff(t *testing.T) {
+	tt := []struct {
+		name string
+		a, b iri.IRI
+		want iri.ComponentDiffs
+	}{
+		{
+			name: "identical",
+			a:    iri.IRI{Scheme: "http", Authority: "a", Path: "/b"},
+			b:    iri.IRI{Scheme: "http", Authority: "a", Path: "/b"},
+			want: nil,
+		},
+		{
+			name: "path and fragment",
+			a:    iri.IRI{Scheme: "http", Authority: "a", Path: "/b", Fragment: "f"},
+			b:    iri.IRI{Scheme: "http", Authority: "a", Path: "/c"},
+			want: iri.ComponentDiffs{
+				{Component: iri.ComponentPath, Old: "/b", New: "/c"},
+				{Component: iri.ComponentFragment, Old: "f", New: ""},
+			},
+		},
+		{
+			name: "force flags only",
+			a:    iri.IRI{Scheme: "http", Query: "q"},
+			b:    iri.IRI{Scheme: "http", Query: "q", ForceQuery: true, ForceAuthority: true},
+			want: iri.ComponentDiffs{
+				{Component: iri.ComponentAuthority, NewForced: true},
+				{Component: iri.ComponentQuery, Old: "q", New: "q", NewForced: true},
+			},
+		},
+		{
+			name: "scheme case",
+			a:    iri.IRI{Scheme: "http"},
+			b:    iri.IRI{Scheme: "HTTP"},
+			want: iri.ComponentDiffs{
+				{Component: iri.ComponentScheme, Old: "http", New: "HTTP"},
+			},
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := iri.Diff(tc.a, tc.b); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Diff() got\n%v\nwant\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComponentDiffsString(t *testing.T) {
+	t.Parallel()
+	a := iri.IRI{Scheme: "http", Authority: "a", Path: "/b"}
+	b := iri.IRI{Scheme: "http", Authority: "a", Path: "/c", ForceFragment: true}
+	want := "path: \"/b\" -> \"/c\"\nfragment: \"\" -> \"\" (forced)"
+	if got := iri.Diff(a, b).String(); got != want {
+		t.Errorf("String() got %q, want %q", got, want)
+	}
+}
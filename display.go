@@ -0,0 +1,46 @@
+package iri
+
+// DisplayString returns the IRI in its most human-readable form.
+//
+// Scheme and host are converted to lowercase, and labels of the host with the ACE prefix "xn--" are
+// decoded as per IRI.HostToUnicode. All percent-encoded characters are decoded that are allowed unencoded in an IRI.
+// Characters that are not allowed, such as space, reserved characters, and bidirectional formatting characters, stay encoded.
+// Components with invalid percent-encoding or Punycode are kept as they are.
+//
+// Decoded hosts can spoof other hosts, such as "аррӏе.com" in Cyrillic for "apple.com".
+// Therefore, hosts that IRI.HostConfusableScripts reports as mixing scripts are shown in their ASCII form,
+// as per IRI.ToURIHostOnly. This includes legitimate hosts that mix scripts, such as Japanese names.
+// Hosts of a single script that resemble hosts of another script are not detected.
+//
+// The result is meant for display only: It is lossy, and parsing it may not result in an equal IRI.
+func (iri IRI) DisplayString() string {
+	display := iri.NormalizeHost()
+	decodeAuthority := true
+	if _, mixed := iri.HostConfusableScripts(); mixed {
+		display = iri.NormalizeCase()
+		converted, err := display.ToURIHostOnly()
+		if err == nil {
+			display = converted
+		}
+		decodeAuthority = err == nil
+	} else if converted, err := display.HostToUnicode(); err == nil {
+		display = converted
+	}
+	if decodeAuthority {
+		display.Authority = decodeForDisplay(display.Authority)
+	}
+	display.Path = decodeForDisplay(display.Path)
+	display.Query = decodeForDisplay(display.Query)
+	display.Fragment = decodeForDisplay(display.Fragment)
+	return display.String()
+}
+
+func decodeForDisplay(s string) string {
+	decoded, err := normalizePercentEncoding(s, func(r rune) bool {
+		return isIUnreserved(r) && !isBidiFormatting(r)
+	}, nil)
+	if err != nil {
+		return s
+	}
+	return decoded
+}
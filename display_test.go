@@ -0,0 +1,52 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestDisplayString(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "https://xn--bcher-kva.example/%E2%82%AC", want: "https://bücher.example/€"},
+		{in: "https://user@XN--MNCHEN-3YA.example:8080/a%20b?q=%C2%B5&r=%26#%7Efrag", want: "https://user@münchen.example:8080/a%20b?q=µ&r=%26#~frag"},
+		{in: "http://xn--r8jz45g.xn--zckzah/%E4%BE%8B", want: "http://xn--r8jz45g.xn--zckzah/例"},
+		{in: "http://xn--eckwd4c7cu47r2wf.jp/", want: "http://xn--eckwd4c7cu47r2wf.jp/"},
+		{in: "https://xn--80ak6aa92e.com/", want: "https://xn--80ak6aa92e.com/"},
+		{in: "https://\u0430\u0440\u0440\u04cf\u0435.com/", want: "https://xn--80ak6aa92e.com/"},
+		{in: "https://%D0%B0pple.com/", want: "https://xn--pple-43d.com/"},
+		{in: "https://xn--80ak6aa92e/", want: "https://\u0430\u0440\u0440\u04cf\u0435/"},
+		{in: "https://example.com/%E2%80%AEtxt.exe", want: "https://example.com/%E2%80%AEtxt.exe"},
+		{in: "https://example.com/a%2Fb", want: "https://example.com/a%2Fb"},
+		{in: "https://xn--zzzzzzzzzzzzzzz.example/", want: "https://xn--zzzzzzzzzzzzzzz.example/"},
+		{in: "mailto:user@example.com", want: "mailto:user@example.com"},
+		{in: "http://xn--/p", want: "http://xn--/p"},
+		{in: "http://xn--a/p", want: "http://xn--a/p"},
+		{in: "http://xn--a.xn--bcher-kva.example/", want: "http://xn--a.bücher.example/"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.DisplayString(); got != tc.want {
+				t.Errorf("DisplayString() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDisplayStringInvalidPercentEncoding(t *testing.T) {
+	t.Parallel()
+	value := iri.IRI{Scheme: "https", Authority: "example.com", Path: "/%FF", Query: "q=%C2%B5"}
+	if got, want := value.DisplayString(), "https://example.com/%FF?q=µ"; got != want {
+		t.Errorf("DisplayString() = %q, want %q", got, want)
+	}
+}
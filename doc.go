@@ -8,4 +8,8 @@
 // "real life" behaviour of existing systems.
 // The implementation of this package is inspired by "net/url", yet follows
 // more strictly the RFC specifications.
+//
+// Values of type IRI consist of strings and flags only, and no method modifies its receiver.
+// They are immutable, and safe to share among goroutines. All functions of this package are
+// safe for concurrent use, unless documented otherwise, such as for type Set.
 package iri
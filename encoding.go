@@ -0,0 +1,21 @@
+package iri
+
+// GobEncode implements the gob.GobEncoder interface.
+//
+// The IRI is encoded as its string, not as its fields. This keeps persisted data
+// independent of the layout of the IRI type.
+func (iri IRI) GobEncode() ([]byte, error) {
+	return []byte(iri.String()), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+//
+// The data is parsed with Parse.
+func (iri *IRI) GobDecode(data []byte) error {
+	parsed, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*iri = parsed
+	return nil
+}
@@ -0,0 +1,45 @@
+package iri_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestGobRoundtrip(t *testing.T) {
+	tt := []struct {
+		in iri.IRI
+	}{
+		{in: iri.IRI{}},
+		{in: iri.IRI{ForceAuthority: true, ForceQuery: true, ForceFragment: true}},
+		{in: iri.IRI{Scheme: "https", Authority: "user@example.com", Path: "/µ/%C2%B5", Query: "q=1", Fragment: "frag1"}},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in.String(), func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(tc.in); err != nil {
+				t.Fatalf("Encode() returned error: %v", err)
+			}
+			var got iri.IRI
+			if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+				t.Fatalf("Decode() returned error: %v", err)
+			}
+			if got != tc.in {
+				t.Errorf("gob roundtrip failed:\n  got:  %#v\n  want: %#v", got, tc.in)
+			}
+		})
+	}
+}
+
+func TestGobDecodeInvalid(t *testing.T) {
+	t.Parallel()
+	var value iri.IRI
+	if err := value.GobDecode([]byte("https://example.com/ ")); err == nil {
+		t.Errorf("GobDecode() did not return an error")
+	}
+}
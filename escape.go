@@ -0,0 +1,235 @@
+package iri
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// Unescape decodes all percent-encoded octets of the given string.
+//
+// A plus sign ('+') is kept literally. This is the decoding for the path, the fragment,
+// and all other components that do not follow the form encoding of HTML.
+// It returns an error if a percent sign is not followed by two hexadecimal digits,
+// or if the decoded octets are not valid UTF-8.
+func Unescape(s string) (string, error) {
+	return unescapeString(s, false)
+}
+
+// QueryUnescape decodes all percent-encoded octets of the given string,
+// and decodes a plus sign ('+') as space.
+//
+// This is the decoding for keys and values of queries as per the
+// "application/x-www-form-urlencoded" format. Use Unescape for all other components.
+// It returns an error if a percent sign is not followed by two hexadecimal digits,
+// or if the decoded octets are not valid UTF-8.
+func QueryUnescape(s string) (string, error) {
+	return unescapeString(s, true)
+}
+
+// QueryValues parses the query as "application/x-www-form-urlencoded" key/value pairs.
+//
+// Pairs are separated by an ampersand ('&'), and keys and values are decoded with QueryUnescape.
+// A pair without an equals sign ('=') has an empty value.
+func (iri IRI) QueryValues() (url.Values, error) {
+	values := url.Values{}
+	if iri.Query == "" {
+		return values, nil
+	}
+	for _, pair := range strings.Split(iri.Query, "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		key, err := QueryUnescape(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query key %q: %w", rawKey, err)
+		}
+		value, err := QueryUnescape(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query value %q: %w", rawValue, err)
+		}
+		values.Add(key, value)
+	}
+	return values, nil
+}
+
+// TolerantUnescape decodes all valid percent-encoded octets of the given string, and keeps any
+// percent sign that is not followed by two hexadecimal digits literally, as in "50%".
+// A plus sign ('+') is kept literally.
+//
+// This is a convenience for real-world input that does not conform to RFC 3987; Use Unescape
+// for valid IRIs. The result may contain invalid UTF-8 if the decoded octets are not valid UTF-8.
+func TolerantUnescape(s string) string {
+	octets := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if isPercentEncoded(s, i) {
+			octets = append(octets, hexToByte[strings.ToUpper(s[i+1:i+3])])
+			i += 2
+			continue
+		}
+		octets = append(octets, s[i])
+	}
+	return string(octets)
+}
+
+// escapeStrayPercent percent-encodes every percent sign that is not followed by two hexadecimal digits as "%25".
+func escapeStrayPercent(s string) string {
+	var result strings.Builder
+	for i := 0; i < len(s); i++ {
+		if (s[i] == '%') && !isPercentEncoded(s, i) {
+			result.WriteString("%25")
+			continue
+		}
+		result.WriteByte(s[i])
+	}
+	return result.String()
+}
+
+// isPercentEncoded returns true if s contains a percent-encoded octet at offset i.
+func isPercentEncoded(s string, i int) bool {
+	return (s[i] == '%') && (i+2 < len(s)) && isHex(s[i+1]) && isHex(s[i+2])
+}
+
+func unescapeString(s string, plusAsSpace bool) (string, error) {
+	octets, err := unescapeOctets(s, plusAsSpace)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(octets) {
+		return "", fmt.Errorf("%q contains invalid UTF-8 after decoding", s)
+	}
+	return string(octets), nil
+}
+
+func unescapeOctets(s string, plusAsSpace bool) ([]byte, error) {
+	octets := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '%':
+			if (i+2 >= len(s)) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+				return nil, fmt.Errorf("%q contains invalid percent-encoding at offset %d", s, i)
+			}
+			octets = append(octets, hexToByte[strings.ToUpper(s[i+1:i+3])])
+			i += 2
+		case plusAsSpace && (s[i] == '+'):
+			octets = append(octets, ' ')
+		default:
+			octets = append(octets, s[i])
+		}
+	}
+	return octets, nil
+}
+
+func isHex(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+// escapeString percent-encodes all characters of s for which isAllowed returns false.
+// Characters are encoded as their UTF-8 octets, using uppercase hexadecimal digits.
+// Octets of s that are not valid UTF-8 are encoded as they are.
+func escapeString(s string, isAllowed func(r rune) bool) string {
+	var result strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !((r == utf8.RuneError) && (size == 1)) && isAllowed(r) {
+			result.WriteString(s[i : i+size])
+		} else {
+			for _, octet := range []byte(s[i : i+size]) {
+				result.WriteString(byteToUppercasePercentEncoding[octet])
+			}
+		}
+		i += size
+	}
+	return result.String()
+}
+
+func isIUnreserved(r rune) bool {
+	return iunreservedRE.MatchString(string(r))
+}
+
+func isSubDelim(r rune) bool {
+	return strings.ContainsRune("!$&'()*+,;=", r)
+}
+
+func isPathChar(r rune) bool {
+	return isIUnreserved(r) || isSubDelim(r) || (r == ':') || (r == '@') || (r == '/')
+}
+
+func isRegNameChar(r rune) bool {
+	return isIUnreserved(r) || isSubDelim(r)
+}
+
+// EscapedForEmbedding returns the string of the IRI, escaped to be used as a query value of another IRI.
+//
+// The characters "?", "#", "&", "=", and space are percent-encoded, so that the IRI cannot
+// change the structure of the outer query. The characters "%" and "+" are percent-encoded as well,
+// so that QueryUnescape of the query value restores the string of the IRI exactly.
+func (iri IRI) EscapedForEmbedding() string {
+	return escapeString(iri.String(), func(r rune) bool {
+		return !strings.ContainsRune("?#&= %+", r)
+	})
+}
+
+// EscapeAuthority percent-encodes all characters of s that are not allowed in an authority.
+// The delimiters "@" and ":", as well as the brackets of IP literals, are kept.
+func EscapeAuthority(s string) string {
+	return escapeString(s, func(r rune) bool {
+		return isRegNameChar(r) || strings.ContainsRune(":@[]", r)
+	})
+}
+
+// EscapePath percent-encodes all characters of s that are not allowed in a path.
+// Slashes are kept as segment delimiters.
+func EscapePath(s string) string {
+	return escapeString(s, isPathChar)
+}
+
+// EscapeQuery percent-encodes all characters of s that are not allowed in a query.
+// Reserved characters that are allowed, such as "&" and "=", are kept, as they structure the query.
+func EscapeQuery(s string) string {
+	return escapeString(s, func(r rune) bool {
+		return isPathChar(r) || (r == '?') || isIPrivate(r)
+	})
+}
+
+// EscapeFragment percent-encodes all characters of s that are not allowed in a fragment.
+func EscapeFragment(s string) string {
+	return escapeString(s, func(r rune) bool {
+		return isPathChar(r) || (r == '?')
+	})
+}
+
+func isIPrivate(r rune) bool {
+	return iprivateRE.MatchString(string(r))
+}
+
+// PathBytes returns the path with all percent-encoded octets decoded, without UTF-8 validation.
+// It returns an error if a percent sign is not followed by two hexadecimal digits.
+func (iri IRI) PathBytes() ([]byte, error) {
+	return unescapeOctets(iri.Path, false)
+}
+
+// QueryBytes returns the query with all percent-encoded octets decoded, without UTF-8 validation.
+// A plus sign ('+') is kept literally, as the query is not split into key/value pairs.
+// It returns an error if a percent sign is not followed by two hexadecimal digits.
+func (iri IRI) QueryBytes() ([]byte, error) {
+	return unescapeOctets(iri.Query, false)
+}
+
+// FragmentBytes returns the fragment with all percent-encoded octets decoded, without UTF-8 validation.
+// It returns an error if a percent sign is not followed by two hexadecimal digits.
+func (iri IRI) FragmentBytes() ([]byte, error) {
+	return unescapeOctets(iri.Fragment, false)
+}
+
+// QueryEscape percent-encodes s so that it can be used as key or value of a query.
+//
+// In addition to the characters that are not allowed in a query, the characters "&", "=", "+",
+// and space are percent-encoded. It is the counterpart to QueryUnescape.
+func QueryEscape(s string) string {
+	return escapeString(s, func(r rune) bool {
+		return (isPathChar(r) || (r == '?') || isIPrivate(r)) && !strings.ContainsRune("&=+", r)
+	})
+}
@@ -0,0 +1,222 @@
+package iri_test
+
+import (
+	"bytes"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestUnescape(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "", want: ""},
+		{in: "/b+c", want: "/b+c"},
+		{in: "/b%20c", want: "/b c"},
+		{in: "/%c2%B5", want: "/µ"},
+		{in: "/%2F", want: "//"},
+		{in: "%", wantErr: true},
+		{in: "%2", wantErr: true},
+		{in: "%zz", wantErr: true},
+		{in: "%FF", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Unescape(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Unescape(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQueryUnescape(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "b+c", want: "b c"},
+		{in: "b%2Bc", want: "b+c"},
+		{in: "b%20c", want: "b c"},
+		{in: "%", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.QueryUnescape(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("QueryUnescape(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPlusIsSpaceOnlyInQuery(t *testing.T) {
+	t.Parallel()
+	value, err := iri.Parse("http://example.com/b+c?a=b+c#b+c")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	path, _ := iri.Unescape(value.Path)
+	if path != "/b+c" {
+		t.Errorf("path decoded to %q, want %q", path, "/b+c")
+	}
+	fragment, _ := iri.Unescape(value.Fragment)
+	if fragment != "b+c" {
+		t.Errorf("fragment decoded to %q, want %q", fragment, "b+c")
+	}
+	values, err := value.QueryValues()
+	if err != nil {
+		t.Fatalf("QueryValues() returned error: %v", err)
+	}
+	if got := values.Get("a"); got != "b c" {
+		t.Errorf("query value decoded to %q, want %q", got, "b c")
+	}
+}
+
+func TestQueryValues(t *testing.T) {
+	tt := []struct {
+		query   string
+		want    url.Values
+		wantErr bool
+	}{
+		{query: "", want: url.Values{}},
+		{query: "a=1&b=2&a=3", want: url.Values{"a": {"1", "3"}, "b": {"2"}}},
+		{query: "a&&b=", want: url.Values{"a": {""}, "b": {""}}},
+		{query: "k%20ey=v%3Dalue", want: url.Values{"k ey": {"v=alue"}}},
+		{query: "a=%FF", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.query, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.IRI{Query: tc.query}.QueryValues()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("QueryValues() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEscapedForEmbedding(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "http://a/b?c=d#e", want: "http://a/b%3Fc%3Dd%23e"},
+		{in: "http://a/b?c=d&e=f", want: "http://a/b%3Fc%3Dd%26e%3Df"},
+		{in: "http://a/b%20c+d/µ", want: "http://a/b%2520c%2Bd/µ"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			inner, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got := inner.EscapedForEmbedding()
+			if got != tc.want {
+				t.Errorf("EscapedForEmbedding() = %q, want %q", got, tc.want)
+			}
+			outer, err := iri.Parse("https://example.com/?target=" + got + "&x=1#outer")
+			if err != nil {
+				t.Fatalf("outer IRI is not valid: %v", err)
+			}
+			if outer.Fragment != "outer" {
+				t.Errorf("outer fragment = %q, want %q", outer.Fragment, "outer")
+			}
+			values, err := outer.QueryValues()
+			if err != nil {
+				t.Fatalf("QueryValues() returned error: %v", err)
+			}
+			if (len(values) != 2) || (values.Get("target") != tc.in) || (values.Get("x") != "1") {
+				t.Errorf("outer query is ambiguous: %v", values)
+			}
+		})
+	}
+}
+
+func TestComponentBytes(t *testing.T) {
+	value := iri.IRI{Path: "/%FF%FE", Query: "%FF%FE", Fragment: "%ff%fe"}
+	tt := []struct {
+		name string
+		get  func() ([]byte, error)
+		want []byte
+	}{
+		{name: "path", get: value.PathBytes, want: []byte{'/', 0xFF, 0xFE}},
+		{name: "query", get: value.QueryBytes, want: []byte{0xFF, 0xFE}},
+		{name: "fragment", get: value.FragmentBytes, want: []byte{0xFF, 0xFE}},
+		{name: "plus and text", get: iri.IRI{Query: "a+µ%20"}.QueryBytes, want: []byte("a+µ ")},
+		{name: "empty", get: iri.IRI{}.QueryBytes, want: []byte{}},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tc.get()
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComponentBytesErrors(t *testing.T) {
+	t.Parallel()
+	if _, err := (iri.IRI{Query: "%F"}).QueryBytes(); err == nil {
+		t.Errorf("QueryBytes() did not return an error")
+	}
+}
+
+func TestTolerantUnescape(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "50%", want: "50%"},
+		{in: "50%25", want: "50%"},
+		{in: "50%2", want: "50%2"},
+		{in: "%zz%41", want: "%zzA"},
+		{in: "%%41", want: "%A"},
+		{in: "a+b%20c", want: "a+b c"},
+		{in: "%C2%B5", want: "µ"},
+		{in: "", want: ""},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			if got := iri.TolerantUnescape(tc.in); got != tc.want {
+				t.Errorf("TolerantUnescape(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package iri
+
+import "strings"
+
+// UnregisterProfile removes the profile registered for the given scheme, so that tests can clean up after themselves.
+func UnregisterProfile(scheme string) {
+	profilesMutex.Lock()
+	defer profilesMutex.Unlock()
+	delete(profiles, strings.ToLower(scheme))
+}
@@ -0,0 +1,91 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+const fileScheme = "file"
+
+// FromFilePath returns a "file" IRI for the given absolute file path.
+//
+// The kind of path is determined from its syntax, independent of the current platform:
+//   - "C:\dir\file" and "C:/dir/file" are Windows drive paths, resulting in "file:///C:/dir/file";
+//   - "\\server\share\file" is a Windows UNC path, resulting in "file://server/share/file";
+//   - "/dir/file" is a POSIX path, resulting in "file:///dir/file".
+//
+// Characters that are not allowed in an IRI path are percent-encoded, while non-ASCII
+// characters are kept as they are. Octets that are not valid UTF-8, such as of Latin-1 file names,
+// are percent-encoded as they are. Relative paths result in an error.
+func FromFilePath(p string) (IRI, error) {
+	switch {
+	case isWindowsDrivePath(p):
+		return IRI{Scheme: fileScheme, ForceAuthority: true, Path: "/" + escapeString(strings.ReplaceAll(p, `\`, "/"), isPathChar)}, nil
+	case strings.HasPrefix(p, `\\`):
+		host, path, _ := strings.Cut(strings.ReplaceAll(p[2:], `\`, "/"), "/")
+		if host == "" {
+			return IRI{}, fmt.Errorf("%q is not a valid UNC path: server is missing", p)
+		}
+		return IRI{
+			Scheme:    fileScheme,
+			Authority: escapeString(host, isRegNameChar),
+			Path:      "/" + escapeString(path, isPathChar),
+		}, nil
+	case strings.HasPrefix(p, "/"):
+		return IRI{Scheme: fileScheme, ForceAuthority: true, Path: escapeString(p, isPathChar)}, nil
+	default:
+		return IRI{}, fmt.Errorf("%q is not an absolute file path", p)
+	}
+}
+
+// FilePath returns the file path of a "file" IRI; It is the reverse of FromFilePath.
+//
+// Like FromFilePath, the kind of the returned path is independent of the current platform:
+// A path with a drive letter is returned as Windows drive path with backslashes,
+// an IRI with a host other than "localhost" is returned as Windows UNC path,
+// and any other path is returned as POSIX path.
+// It returns an error if the IRI does not have the "file" scheme, has a query,
+// or if a path segment decodes to contain a slash.
+// The path is not required to be valid UTF-8, so that file names in other encodings are kept.
+func (iri IRI) FilePath() (string, error) {
+	if !strings.EqualFold(iri.Scheme, fileScheme) {
+		return "", fmt.Errorf("%q is not a file IRI", iri)
+	}
+	if iri.hasQuery() {
+		return "", fmt.Errorf("%q is not a valid file IRI: query is not supported", iri)
+	}
+	var segments []string
+	for _, rawSegment := range strings.Split(iri.Path, "/") {
+		octets, err := unescapeOctets(rawSegment, false)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid file IRI: %w", iri, err)
+		}
+		segment := string(octets)
+		if strings.Contains(segment, "/") {
+			return "", fmt.Errorf("%q is not a valid file IRI: segment %q contains an encoded slash", iri, rawSegment)
+		}
+		segments = append(segments, segment)
+	}
+	path := strings.Join(segments, "/")
+	host := iri.Host()
+	switch {
+	case (host != "") && !strings.EqualFold(host, "localhost"):
+		decodedHost, err := Unescape(host)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid file IRI: %w", iri, err)
+		}
+		return `\\` + decodedHost + strings.ReplaceAll(path, "/", `\`), nil
+	case isWindowsDrivePath(strings.TrimPrefix(path, "/")):
+		return strings.ReplaceAll(strings.TrimPrefix(path, "/"), "/", `\`), nil
+	default:
+		return path, nil
+	}
+}
+
+func isWindowsDrivePath(p string) bool {
+	return (len(p) >= 3) && isASCIILetter(p[0]) && (p[1] == ':') && ((p[2] == '\\') || (p[2] == '/'))
+}
+
+func isASCIILetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
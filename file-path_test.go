@@ -0,0 +1,136 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestFromFilePath(t *testing.T) {
+	tt := []struct {
+		in       string
+		want     string
+		wantPath string
+	}{
+		{in: "/home/user/µ file.txt", want: "file:///home/user/µ%20file.txt"},
+		{in: "/", want: "file:///"},
+		{in: "/tmp/100%/a#b?c", want: "file:///tmp/100%25/a%23b%3Fc"},
+		{in: `C:\x`, want: "file:///C:/x"},
+		{in: `c:\Program Files\µ`, want: "file:///c:/Program%20Files/µ"},
+		{in: "C:/x/y", want: "file:///C:/x/y", wantPath: `C:\x\y`},
+		{in: `\\server\share\dir\file.txt`, want: "file://server/share/dir/file.txt"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.FromFilePath(tc.in)
+			if err != nil {
+				t.Fatalf("FromFilePath() returned error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("FromFilePath(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+			if _, err := iri.Parse(got.String()); err != nil {
+				t.Errorf("result %q is not a valid IRI: %v", got, err)
+			}
+			wantPath := tc.wantPath
+			if wantPath == "" {
+				wantPath = tc.in
+			}
+			if path, err := got.FilePath(); (err != nil) || (path != wantPath) {
+				t.Errorf("FilePath() = %q, %v, want %q", path, err, wantPath)
+			}
+		})
+	}
+}
+
+func TestFromFilePathInvalidUTF8(t *testing.T) {
+	tt := []struct {
+		in       string
+		want     string
+		wantPath string
+	}{
+		{in: "/tmp/caf\xe9.txt", want: "file:///tmp/caf%E9.txt", wantPath: "/tmp/caf\xe9.txt"},
+		{in: "/tmp/\ufffd\xff", want: "file:///tmp/%EF%BF%BD%FF", wantPath: "/tmp/\ufffd\xff"},
+		{in: "C:/caf\xe9", want: "file:///C:/caf%E9", wantPath: "C:\\caf\xe9"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.FromFilePath(tc.in)
+			if err != nil {
+				t.Fatalf("FromFilePath() returned error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("FromFilePath(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+			if _, err := iri.Parse(got.String(), iri.SkipPercentValidation()); err != nil {
+				t.Errorf("result %q is not a valid IRI: %v", got, err)
+			}
+			if path, err := got.FilePath(); (err != nil) || (path != tc.wantPath) {
+				t.Errorf("FilePath() = %q, %v, want %q", path, err, tc.wantPath)
+			}
+		})
+	}
+}
+
+func TestFromFilePathErrors(t *testing.T) {
+	tt := []struct {
+		in string
+	}{
+		{in: ""},
+		{in: "relative/path"},
+		{in: "C:relative"},
+		{in: `\\`},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			if got, err := iri.FromFilePath(tc.in); err == nil {
+				t.Errorf("FromFilePath(%q) did not return an error, got %q", tc.in, got)
+			}
+		})
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "file:///etc/hosts", want: "/etc/hosts"},
+		{in: "file://localhost/etc/hosts", want: "/etc/hosts"},
+		{in: "FILE:///etc/%C2%B5", want: "/etc/µ"},
+		{in: "file:/etc/hosts", want: "/etc/hosts"},
+		{in: "file:///D:/data", want: `D:\data`},
+		{in: "file://server/share", want: `\\server\share`},
+		{in: "file:///a%2Fb", wantErr: true},
+		{in: "file:///a?q", wantErr: true},
+		{in: "https://example.com/a", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, err := value.FilePath()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("FilePath() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
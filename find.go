@@ -0,0 +1,89 @@
+package iri
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// findCandidateRE matches the candidates of FindAll: a scheme, a colon, and a run of characters
+// up to whitespace or a character that is never allowed in an IRI.
+var findCandidateRE = mustCompileNamed("findCandidateRE", `[a-zA-Z][a-zA-Z0-9+.\-]*:[^\s<>"{}|\\^`+"`"+`]+`)
+
+// FindOptions configure how FindAllWithOptions determines the end of found IRIs.
+type FindOptions struct {
+	// TrailingPunctuation is the set of characters that are trimmed from the end of a candidate,
+	// as they typically belong to the surrounding text.
+	TrailingPunctuation string
+	// KeepBalancedBrackets keeps a trailing ")" or "]" if the candidate contains at least as many
+	// of the respective opening brackets, such as in "https://example.org/wiki/Go_(language)".
+	KeepBalancedBrackets bool
+}
+
+// DefaultFindOptions returns the options that FindAll uses.
+// They trim the characters `.,;:!?'")]`, yet keep balanced brackets.
+func DefaultFindOptions() FindOptions {
+	return FindOptions{
+		TrailingPunctuation:  `.,;:!?'")]`,
+		KeepBalancedBrackets: true,
+	}
+}
+
+// FindAll returns all absolute IRIs that are found in the given text, in order of appearance.
+// It uses the DefaultFindOptions; See FindAllWithOptions for details.
+//
+// For example, in the text "see https://a/b, and ...", the found IRI is "https://a/b".
+func FindAll(text string) []IRI {
+	return FindAllWithOptions(text, DefaultFindOptions())
+}
+
+// FindAllWithOptions returns all absolute IRIs that are found in the given text, in order of appearance.
+//
+// This is a heuristic, which works as follows:
+//   - A candidate begins with a scheme followed by a colon, such as "https:" or "urn:";
+//   - The candidate extends up to, and excluding, the next whitespace or any of the characters
+//     `<>"{}|\^` and the backtick, which are never allowed in an IRI;
+//   - Characters of the trailing punctuation of the options are trimmed from the end of the candidate,
+//     unless they are balanced brackets that are kept as per the options;
+//   - The remaining candidate is returned if it is not empty after the colon and can be parsed.
+func FindAllWithOptions(text string, opts FindOptions) []IRI {
+	var result []IRI
+	for _, candidate := range findCandidateRE.FindAllString(text, -1) {
+		candidate = opts.trim(candidate)
+		if strings.HasSuffix(candidate, ":") || !strings.Contains(candidate, ":") {
+			continue
+		}
+		parsed, err := Parse(candidate)
+		if err != nil {
+			continue
+		}
+		result = append(result, parsed)
+	}
+	return result
+}
+
+func (opts FindOptions) trim(candidate string) string {
+	for candidate != "" {
+		last, size := utf8.DecodeLastRuneInString(candidate)
+		if !strings.ContainsRune(opts.TrailingPunctuation, last) {
+			break
+		}
+		if opts.KeepBalancedBrackets && isBalancedClosingBracket(candidate, last) {
+			break
+		}
+		candidate = candidate[:len(candidate)-size]
+	}
+	return candidate
+}
+
+func isBalancedClosingBracket(s string, closing rune) bool {
+	var opening rune
+	switch closing {
+	case ')':
+		opening = '('
+	case ']':
+		opening = '['
+	default:
+		return false
+	}
+	return strings.Count(s, string(opening)) >= strings.Count(s, string(closing))
+}
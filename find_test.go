@@ -0,0 +1,77 @@
+package iri_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestFindAll(t *testing.T) {
+	tt := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "trailing comma", in: "see https://a/b, and more", want: []string{"https://a/b"}},
+		{name: "trailing period", in: "Visit https://example.com/µ?q=1#frag.", want: []string{"https://example.com/µ?q=1#frag"}},
+		{name: "several", in: "mailto:user@example.com or tel:+1-816-555-1212; urn:isbn:0451450523!", want: []string{"mailto:user@example.com", "tel:+1-816-555-1212", "urn:isbn:0451450523"}},
+		{name: "angle brackets", in: "<https://a/b>", want: []string{"https://a/b"}},
+		{name: "quotes", in: `href="https://a/b?c=d&e=f"`, want: []string{"https://a/b?c=d&e=f"}},
+		{name: "parentheses", in: "(https://a/b)", want: []string{"https://a/b"}},
+		{name: "words with colon", in: "Note: this is not: an IRI", want: nil},
+		{name: "invalid candidate", in: "https://a/%zz and https://b", want: []string{"https://b"}},
+		{name: "line breaks", in: "first\nhttps://a\nhttps://b", want: []string{"https://a", "https://b"}},
+		{name: "none", in: "no IRIs here", want: nil},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, found := range iri.FindAll(tc.in) {
+				got = append(got, found.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FindAll(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindAllWithOptions(t *testing.T) {
+	markdown := iri.FindOptions{TrailingPunctuation: ".,)", KeepBalancedBrackets: true}
+	plain := iri.FindOptions{TrailingPunctuation: ".,)"}
+	tt := []struct {
+		name string
+		in   string
+		opts iri.FindOptions
+		want []string
+	}{
+		{name: "balanced parentheses inside", in: "see https://example.org/wiki/Go_(language).", opts: markdown, want: []string{"https://example.org/wiki/Go_(language)"}},
+		{name: "parentheses outside", in: "(see https://example.org/wiki/Go)", opts: markdown, want: []string{"https://example.org/wiki/Go"}},
+		{name: "both", in: "(see https://example.org/wiki/Go_(language))", opts: markdown, want: []string{"https://example.org/wiki/Go_(language)"}},
+		{name: "markdown link", in: "[Go](https://example.org/wiki/Go_(language))", opts: markdown, want: []string{"https://example.org/wiki/Go_(language)"}},
+		{name: "balanced disabled", in: "see https://example.org/wiki/Go_(language).", opts: plain, want: []string{"https://example.org/wiki/Go_(language"}},
+		{name: "nothing trimmed", in: "see https://a/b.", opts: iri.FindOptions{}, want: []string{"https://a/b."}},
+		{name: "custom set", in: "see https://a/b!", opts: iri.FindOptions{TrailingPunctuation: "!"}, want: []string{"https://a/b"}},
+		{name: "non-ASCII punctuation", in: "see https://a/b\u2082\u3002", opts: iri.FindOptions{TrailingPunctuation: ".\u3002"}, want: []string{"https://a/b\u2082"}},
+		{name: "non-ASCII sharing last byte", in: "see https://a/b\u2082", opts: iri.FindOptions{TrailingPunctuation: "\u3002"}, want: []string{"https://a/b\u2082"}},
+		{name: "default keeps IP literal", in: "at http://[::1]:80 and http://[::1].", opts: iri.DefaultFindOptions(), want: []string{"http://[::1]:80", "http://[::1]"}},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, found := range iri.FindAllWithOptions(tc.in, tc.opts) {
+				got = append(got, found.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FindAllWithOptions(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
@@ -2,3 +2,7 @@
 module github.com/contomap/iri
 
 go 1.19
+
+require golang.org/x/net v0.35.0
+
+require golang.org/x/text v0.22.0 // indirect
@@ -0,0 +1,22 @@
+package iri
+
+import (
+	"crypto/sha256"
+	"hash/fnv"
+)
+
+// Sum64 returns the 64-bit FNV-1a hash of the canonical string of the IRI.
+//
+// Two IRIs that are equal under EqualNormalized produce the same hash.
+func (iri IRI) Sum64() uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(iri.CanonicalString()))
+	return h.Sum64()
+}
+
+// SHA256 returns the SHA-256 checksum of the canonical string of the IRI.
+//
+// Two IRIs that are equal under EqualNormalized produce the same checksum.
+func (iri IRI) SHA256() [32]byte {
+	return sha256.Sum256([]byte(iri.CanonicalString()))
+}
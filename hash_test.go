@@ -0,0 +1,51 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestHashIsStableForNormalizedEquality(t *testing.T) {
+	tt := []struct {
+		a, b string
+	}{
+		{a: "https://example.com/µ", b: "https://example.com/%C2%B5"},
+		{a: "https://example.com/µ", b: "HTTPS://EXAMPLE.COM/%c2%b5"},
+		{a: "https://example.com/a/c", b: "https://example.com/a/b/../c"},
+		{a: "urn:uuid:6c689097-8097-4421-9def-05e835f2dbb8", b: "URN:uuid:6c689097-8097-4421-9def-05e835f2dbb8"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			t.Parallel()
+			a, errA := iri.Parse(tc.a)
+			b, errB := iri.Parse(tc.b)
+			if (errA != nil) || (errB != nil) {
+				t.Fatalf("Parse() returned errors: %v, %v", errA, errB)
+			}
+			if !iri.EqualNormalized(a, b) {
+				t.Fatalf("EqualNormalized(%q, %q) = false", a, b)
+			}
+			if a.Sum64() != b.Sum64() {
+				t.Errorf("Sum64() differs: %x != %x", a.Sum64(), b.Sum64())
+			}
+			if a.SHA256() != b.SHA256() {
+				t.Errorf("SHA256() differs: %x != %x", a.SHA256(), b.SHA256())
+			}
+		})
+	}
+}
+
+func TestHashDiffersForDifferentIRIs(t *testing.T) {
+	t.Parallel()
+	a, _ := iri.Parse("https://example.com/a")
+	b, _ := iri.Parse("https://example.com/b")
+	if a.Sum64() == b.Sum64() {
+		t.Errorf("Sum64() is equal for %q and %q", a, b)
+	}
+	if a.SHA256() == b.SHA256() {
+		t.Errorf("SHA256() is equal for %q and %q", a, b)
+	}
+}
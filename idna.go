@@ -0,0 +1,173 @@
+package iri
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// acePrefix is the prefix of labels that are encoded with Punycode, as per RFC 3490.
+const acePrefix = "xn--"
+
+// ToURIHostOnly returns an IRI in which the host is converted to its ASCII form,
+// while all other components are kept as they are, including non-ASCII characters.
+//
+// This suits systems that accept IRI paths, yet require hosts that can be resolved with DNS.
+// Labels of the host with non-ASCII characters are converted with the lookup profile of
+// UTS #46, which maps and validates them before they are encoded with Punycode, prefixed by "xn--".
+// ASCII labels are kept as they are, so that names such as "my_host" stay valid.
+func (iri IRI) ToURIHostOnly() (IRI, error) {
+	if iri.Authority == "" {
+		return iri, nil
+	}
+	parts := splitAuthority(iri.Authority)
+	host, err := hostToASCII(parts.host)
+	if err != nil {
+		return IRI{}, fmt.Errorf("%q cannot be converted: %w", iri, err)
+	}
+	parts.host = host
+	converted := iri
+	converted.Authority = parts.String()
+	return converted, nil
+}
+
+// HostConfusableScripts returns the sorted names of the Unicode scripts used in the host,
+// and whether the host mixes scripts in a way that may be confusable, such as a Latin "a"
+// next to a Cyrillic "а" (U+0430).
+//
+// The host is percent-decoded, and labels with the ACE prefix "xn--" are decoded as per HostToUnicode.
+// The scripts "Common" and "Inherited", used for digits, punctuation, and combining marks,
+// are not considered. The policy is simple: A host is confusable if it uses more than one script.
+// Hosts that legitimately mix scripts, such as Japanese names with Han and Hiragana, are reported as well.
+func (iri IRI) HostConfusableScripts() ([]string, bool) {
+	host := iri.Host()
+	if decoded, err := Unescape(host); err == nil {
+		host = decoded
+	}
+	if decoded, err := hostToUnicode(host); err == nil {
+		host = decoded
+	}
+	found := map[string]struct{}{}
+	for _, r := range host {
+		if name, known := scriptOf(r); known && (name != "Common") && (name != "Inherited") {
+			found[name] = struct{}{}
+		}
+	}
+	scripts := make([]string, 0, len(found))
+	for name := range found {
+		scripts = append(scripts, name)
+	}
+	sort.Strings(scripts)
+	return scripts, len(scripts) > 1
+}
+
+func scriptOf(r rune) (string, bool) {
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// HostToUnicode returns an IRI in which every label of the host with the ACE prefix "xn--"
+// is decoded with Punycode. This is the counterpart to ToURIHostOnly; All other components are kept.
+//
+// A label that is valid Punycode, yet decodes to an empty string or to characters that are not valid
+// in a host as per UTS #46 or RFC 3987, is kept as it is. An error is returned for invalid Punycode.
+func (iri IRI) HostToUnicode() (IRI, error) {
+	if iri.Authority == "" {
+		return iri, nil
+	}
+	parts := splitAuthority(iri.Authority)
+	host, err := hostToUnicode(parts.host)
+	if err != nil {
+		return IRI{}, fmt.Errorf("%q cannot be converted: %w", iri, err)
+	}
+	parts.host = host
+	converted := iri
+	converted.Authority = parts.String()
+	return converted, nil
+}
+
+// hostToASCII converts a registered name into its ASCII form, as per the ToASCII operation of UTS #46.
+//
+// Percent-encoded octets are decoded, and every label that contains non-ASCII characters is converted
+// with the lookup profile of package idna. ASCII labels are only percent-encoded where necessary.
+// IP literals and IPv4 addresses are kept.
+func hostToASCII(host string) (string, error) {
+	if strings.HasPrefix(host, "[") || ipV4AddressRE.MatchString(host) {
+		return host, nil
+	}
+	decoded, err := Unescape(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", host, err)
+	}
+	labels := strings.Split(decoded, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			labels[i] = escapeString(label, isRegNameChar)
+			continue
+		}
+		encoded, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			return "", fmt.Errorf("invalid host %q: %w", host, err)
+		}
+		labels[i] = encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// hostToUnicode converts a registered name into its Unicode form, as per the ToUnicode operation of UTS #46.
+//
+// Every label with the ACE prefix "xn--" is decoded with the lookup profile of package idna; All other
+// labels, as well as IP literals and IPv4 addresses, are kept as they are. A label that is valid Punycode,
+// yet decodes to an empty string or to characters that are not allowed in a host, is kept as it is as well.
+func hostToUnicode(host string) (string, error) {
+	if strings.HasPrefix(host, "[") || ipV4AddressRE.MatchString(host) {
+		return host, nil
+	}
+	labels := strings.Split(host, ".")
+	for i, label := range labels {
+		if (len(label) < len(acePrefix)) || !strings.EqualFold(label[:len(acePrefix)], acePrefix) {
+			continue
+		}
+		decoded, err := idna.Lookup.ToUnicode(label)
+		if err != nil {
+			if _, punycodeErr := idna.Punycode.ToUnicode(label); punycodeErr != nil {
+				return "", fmt.Errorf("invalid host %q: %w", host, punycodeErr)
+			}
+			continue
+		}
+		if isDisplayableLabel(decoded) {
+			labels[i] = decoded
+		}
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// isDisplayableLabel returns true if the decoded label is not empty and consists of iunreserved characters only.
+func isDisplayableLabel(label string) bool {
+	if label == "" {
+		return false
+	}
+	for _, r := range label {
+		if !isIUnreserved(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package iri_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestToURIHostOnly(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "https://bücher.example/Ῥόδος", want: "https://xn--bcher-kva.example/Ῥόδος"},
+		{in: "https://Bücher.example/", want: "https://xn--bcher-kva.example/"},
+		{in: "https://b%C3%BCcher.example", want: "https://xn--bcher-kva.example"},
+		{in: "https://user@münchen.example:8080/µ?€#€", want: "https://user@xn--mnchen-3ya.example:8080/µ?€#€"},
+		{in: "http://例え.テスト/", want: "http://xn--r8jz45g.xn--zckzah/"},
+		{in: "http://ドメイン名例.jp", want: "http://xn--eckwd4c7cu47r2wf.jp"},
+		{in: "https://b\u00fccher\uff0eexample/", want: "https://xn--bcher-kva.example/"},
+		{in: "https://\ufb01sch\u00e9.example/", want: "https://xn--fisch-fsa.example/"},
+		{in: "https://example.com/µ", want: "https://example.com/µ"},
+		{in: "https://a%20b.example", want: "https://a%20b.example"},
+		{in: "https://[::1]/µ", want: "https://[::1]/µ"},
+		{in: "https://1.2.3.4/µ", want: "https://1.2.3.4/µ"},
+		{in: "mailto:user@bücher.example", want: "mailto:user@bücher.example"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, err := value.ToURIHostOnly()
+			if err != nil {
+				t.Fatalf("ToURIHostOnly() returned error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("ToURIHostOnly() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHostConfusableScripts(t *testing.T) {
+	tt := []struct {
+		in          string
+		wantScripts []string
+		wantMixed   bool
+	}{
+		{in: "https://example.com/", wantScripts: []string{"Latin"}, wantMixed: false},
+		{in: "https://bücher.example/", wantScripts: []string{"Latin"}, wantMixed: false},
+		{in: "https://xn--bcher-kva.example/", wantScripts: []string{"Latin"}, wantMixed: false},
+		{in: "https://аpple.com/", wantScripts: []string{"Cyrillic", "Latin"}, wantMixed: true},
+		{in: "https://xn--80ak6aa92e.com/", wantScripts: []string{"Cyrillic", "Latin"}, wantMixed: true},
+		{in: "https://xn--80ak6aa92e/", wantScripts: []string{"Cyrillic"}, wantMixed: false},
+		{in: "https://%D0%B0pple.com/", wantScripts: []string{"Cyrillic", "Latin"}, wantMixed: true},
+		{in: "http://例え.テスト/", wantScripts: []string{"Han", "Hiragana", "Katakana"}, wantMixed: true},
+		{in: "https://192.0.2.16/", wantScripts: []string{}, wantMixed: false},
+		{in: "mailto:user@аpple.com", wantScripts: []string{}, wantMixed: false},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			scripts, mixed := value.HostConfusableScripts()
+			if !reflect.DeepEqual(scripts, tc.wantScripts) {
+				t.Errorf("HostConfusableScripts() scripts = %q, want %q", scripts, tc.wantScripts)
+			}
+			if mixed != tc.wantMixed {
+				t.Errorf("HostConfusableScripts() mixed = %v, want %v", mixed, tc.wantMixed)
+			}
+		})
+	}
+}
+
+func TestHostToUnicode(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "https://xn--bcher-kva.example/%E2%82%AC", want: "https://bücher.example/%E2%82%AC"},
+		{in: "http://xn--r8jz45g.xn--zckzah/", want: "http://例え.テスト/"},
+		{in: "https://user@xn--mnchen-3ya.example:8080", want: "https://user@münchen.example:8080"},
+		{in: "https://example.com/", want: "https://example.com/"},
+		{in: "https://[::1]/", want: "https://[::1]/"},
+		{in: "mailto:user@xn--bcher-kva.example", want: "mailto:user@xn--bcher-kva.example"},
+		{in: "https://xn--zzzzzzzzzzzzzzz.example/", wantErr: true},
+		{in: "http://xn--/p", want: "http://xn--/p"},
+		{in: "http://xn--a/p", want: "http://xn--a/p"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, err := value.HostToUnicode()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("HostToUnicode() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package iri
+
+import "sync"
+
+// Interner canonicalizes IRIs and shares equal ones, to reduce memory for large collections
+// with many duplicates, such as graphs.
+//
+// The zero value is an empty interner, ready to use. An Interner is safe for concurrent use.
+// Interned IRIs are never released; Use a new Interner to start over.
+type Interner struct {
+	mutex   sync.Mutex
+	members map[string]IRI
+}
+
+// Intern returns the normalized form of the IRI, as per Normalize. The first normalized IRI
+// of each canonical string is kept, and returned for all IRIs with the same canonical string,
+// so that their component strings share the same memory.
+//
+// Should the IRI contain invalid percent-encoding, the remaining normalization steps are
+// still applied, leaving the percent-encoding as is. See IRI.CanonicalString.
+func (interner *Interner) Intern(iri IRI) IRI {
+	normalized, err := iri.Normalize()
+	if err != nil {
+		normalized = iri.normalizeSyntax()
+	}
+	key := normalized.String()
+	interner.mutex.Lock()
+	defer interner.mutex.Unlock()
+	if member, exists := interner.members[key]; exists {
+		return member
+	}
+	if interner.members == nil {
+		interner.members = make(map[string]IRI)
+	}
+	interner.members[key] = normalized
+	return normalized
+}
+
+// Len returns the number of distinct IRIs that were interned.
+func (interner *Interner) Len() int {
+	interner.mutex.Lock()
+	defer interner.mutex.Unlock()
+	return len(interner.members)
+}
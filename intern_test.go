@@ -0,0 +1,62 @@
+package iri_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestInterner(t *testing.T) {
+	t.Parallel()
+	var interner iri.Interner
+	a, errA := iri.Parse("HTTP://Example.com/%7euser/./a?q=%c2%b5#f")
+	b, errB := iri.Parse("http://example.com/~user/a?q=µ#f")
+	if (errA != nil) || (errB != nil) {
+		t.Fatalf("Parse() returned errors: %v, %v", errA, errB)
+	}
+	internedA := interner.Intern(a)
+	internedB := interner.Intern(b)
+	if internedA != internedB {
+		t.Errorf("Intern() returned different IRIs: %#v, %#v", internedA, internedB)
+	}
+	if want := "http://example.com/~user/a?q=µ#f"; internedA.String() != want {
+		t.Errorf("Intern() = %q, want %q", internedA, want)
+	}
+	if got := interner.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+	interner.Intern(iri.IRI{Scheme: "http", Authority: "example.com", Path: "/other"})
+	if got := interner.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+}
+
+func TestInternerInvalidPercentEncoding(t *testing.T) {
+	t.Parallel()
+	var interner iri.Interner
+	got := interner.Intern(iri.IRI{Scheme: "HTTP", Authority: "example.com", Path: "/a/../%ff"})
+	if want := "http://example.com/%FF"; got.String() != want {
+		t.Errorf("Intern() = %q, want %q", got, want)
+	}
+}
+
+func TestInternerConcurrentUse(t *testing.T) {
+	t.Parallel()
+	var interner iri.Interner
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				interner.Intern(iri.IRI{Scheme: "http", Authority: "example.com", Path: "/%7e"})
+				interner.Intern(iri.IRI{Scheme: "http", Authority: "example.com", Path: "/~"})
+			}
+		}()
+	}
+	wg.Wait()
+	if got := interner.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+}
@@ -12,6 +12,11 @@ import (
 // This type is not a "drop-in" replacement for "net/uri.URI". See package
 // comments for details.
 //
+// A non-empty Authority, Query, or Fragment is always written with its delimiter.
+// The respective Force* flag matters only if the component is empty: It then decides
+// whether the delimiter is written nonetheless. Parse sets a Force* flag only for
+// present, yet empty components, so that the string of the parsed IRI equals the input.
+//
 // See https://www.ietf.org/rfc/rfc3987.html
 type IRI struct {
 	Scheme         string
@@ -31,19 +36,41 @@ type IRI struct {
 // Finally, any percent-encoding is verified - yet the returned IRI will have the original percent encoding
 // maintained.
 // If any of these steps produce an error, this function returns an error and an empty IRI.
-func Parse(s string) (IRI, error) {
+//
+// The given options can further restrict or modify the parsing. Without options, the
+// string of the returned IRI is equal to the input.
+func Parse(s string, opts ...ParseOption) (IRI, error) {
+	options := newParseOptions(opts)
+	if options.rejectEmpty && (s == "") {
+		return IRI{}, fmt.Errorf("%q is not a valid IRI: empty input", s)
+	}
+	if options.escapeStrayPercent {
+		s = escapeStrayPercent(s)
+	}
 	match := uriRE.FindStringSubmatch(s) // It is not possible to not match the regular expression; If it is, add a test
 	scheme := match[uriRESchemeGroup]
 	authority := match[uriREAuthorityGroup]
 	path := match[uriREPathGroup]
 	query := match[uriREQueryGroup]
 	fragment := match[uriREFragmentGroup]
+	if err := options.checkLimits(authority, path, query); err != nil {
+		return IRI{}, fmt.Errorf("%q is not a valid IRI: %w", s, err)
+	}
+	if strings.HasPrefix(s, ":") {
+		return IRI{}, fmt.Errorf("%q is not a valid IRI: empty scheme before colon", s)
+	}
 	if scheme != "" && !schemeRE.MatchString(scheme) {
 		return IRI{}, fmt.Errorf("%q is not a valid IRI: invalid scheme %q does not match regexp %s", s, scheme, schemeRE)
 	}
 	if authority != "" && !iauthorityRE.MatchString(authority) {
+		if host := splitAuthority(authority).host; strings.HasPrefix(host, "[") && !ipLiteralRE.MatchString(host) {
+			return IRI{}, fmt.Errorf("%q is not a valid IRI: invalid IP literal %q", s, host)
+		}
 		return IRI{}, fmt.Errorf("%q is not a valid IRI: invalid authority %q does not match regexp %s", s, authority, iauthorityRE)
 	}
+	if err := options.checkAuthority(authority); err != nil {
+		return IRI{}, fmt.Errorf("%q is not a valid IRI: %w", s, err)
+	}
 	if path != "" && !ipathRE.MatchString(path) {
 		return IRI{}, fmt.Errorf("%q is not a valid IRI: invalid path %q does not match regexp %s", s, path, ipathRE)
 	}
@@ -65,13 +92,36 @@ func Parse(s string) (IRI, error) {
 		Fragment:       fragment,
 	}
 
-	if _, err := NormalizePercentEncoding(parsed); err != nil {
-		return IRI{}, fmt.Errorf("%q is not a valid IRI: invalid percent encoding: %w", s, err)
+	if !options.skipPercentCheck {
+		if _, err := NormalizePercentEncoding(parsed); err != nil {
+			return IRI{}, fmt.Errorf("%q is not a valid IRI: invalid percent encoding: %w", s, err)
+		}
+	}
+	if options.lowercaseScheme {
+		parsed.Scheme = strings.ToLower(parsed.Scheme)
+	}
+	parsed, err := options.adjustEmptyPath(parsed)
+	if err != nil {
+		return IRI{}, fmt.Errorf("%q is not a valid IRI: %w", s, err)
 	}
 
 	return parsed, nil
 }
 
+// ParseInto parses a string like Parse, and writes the result into the IRI.
+// On error, the IRI is left unchanged.
+//
+// This allows to reuse a variable in loops, as in
+// "for scanner.Scan() { err := value.ParseInto(scanner.Text()) }".
+func (iri *IRI) ParseInto(s string, opts ...ParseOption) error {
+	parsed, err := Parse(s, opts...)
+	if err != nil {
+		return err
+	}
+	*iri = parsed
+	return nil
+}
+
 // String reassembles the IRI into an IRI string.
 // Any components that have been manually set must comply to the format;
 // This function performs no further escaping.
@@ -97,6 +147,15 @@ func (iri IRI) String() string {
 	return result.String()
 }
 
+// StringEncodingSpaces works like String, yet percent-encodes any space character (' ') as "%20".
+//
+// This is a forgiving serialization for manually created IRIs that contain spaces, which are
+// not valid in any component. All other characters are kept as they are; Use SafeString
+// for a guaranteed valid serialization.
+func (iri IRI) StringEncodingSpaces() string {
+	return strings.ReplaceAll(iri.String(), " ", "%20")
+}
+
 func (iri IRI) hasScheme() bool    { return iri.Scheme != "" }
 func (iri IRI) hasAuthority() bool { return iri.ForceAuthority || iri.Authority != "" }
 func (iri IRI) hasQuery() bool     { return iri.ForceQuery || iri.Query != "" }
@@ -104,44 +163,144 @@ func (iri IRI) hasFragment() bool  { return iri.ForceFragment || iri.Fragment !=
 
 // ResolveReference resolves an IRI reference to an absolute IRI from an absolute
 // base IRI, per RFC 3986 Section 5.2. The IRI reference may be relative or absolute.
+//
+// Resolving a reference with a path against an opaque base, such as "baz" against "urn:foo:bar",
+// is not meaningful, as the base has no hierarchical path. In this case, the base is returned without
+// its fragment; The query and the fragment of the reference are dropped as well.
+// Other references, such as "#frag" or "?q", keep the path of an opaque base as it is.
+// See IRI.IsOpaque.
 func (iri IRI) ResolveReference(other IRI) IRI {
 	return resolveReference(iri, other)
 }
 
+// ResolveAll resolves each of the given IRI references like ResolveReference, and returns
+// the resolved IRIs in the same order.
+//
+// This is more efficient than calling ResolveReference for each reference, as the
+// directory and the path of the base IRI are determined only once.
+func (iri IRI) ResolveAll(refs []IRI) []IRI {
+	resolver := newBaseResolver(iri)
+	resolved := make([]IRI, len(refs))
+	for i, ref := range refs {
+		resolved[i] = resolver.resolve(ref)
+	}
+	return resolved
+}
+
+// ResolveReferenceStrict resolves an IRI reference like ResolveReference, yet
+// returns an error if the base IRI is not absolute, i.e. has no scheme.
+// It also returns an error for a relative reference with a path, but without authority,
+// if the base is opaque.
+//
+// RFC 3986 Section 5.2.1 requires the base to be an absolute URI.
+func (iri IRI) ResolveReferenceStrict(other IRI) (IRI, error) {
+	if !iri.hasScheme() {
+		return IRI{}, fmt.Errorf("%q is not a valid base IRI: scheme is missing", iri)
+	}
+	if iri.IsOpaque() && !other.hasScheme() && !other.hasAuthority() && (other.Path != "") {
+		return IRI{}, fmt.Errorf("%q cannot be resolved against opaque base IRI %q", other, iri)
+	}
+	return resolveReference(iri, other), nil
+}
+
 // NormalizePercentEncoding returns an IRI that replaces any unnecessarily
 // percent-escaped characters with unescaped characters.
 //
 // RFC3987 discusses this normalization procedure in 5.3.2.3:
 // https://www.ietf.org/rfc/rfc3987.html#section-5.3.2.3.
 func NormalizePercentEncoding(iri IRI) (IRI, error) {
+	return normalizeComponentsPercentEncoding(iri, isIUnreserved, nil)
+}
+
+// Stats describes the effect of percent-encoding normalization, as returned by NormalizePercentEncodingWithStats.
+type Stats struct {
+	// Decoded is the number of percent-encoded characters that were decoded.
+	Decoded int
+	// Kept is the number of percent-encoded characters that stay percent-encoded.
+	Kept int
+	// BytesSaved is the number of bytes by which the normalized IRI is shorter.
+	BytesSaved int
+}
+
+// NormalizePercentEncodingWithStats works like NormalizePercentEncoding, and additionally returns
+// how many percent-encoded characters were decoded or kept, and how many bytes were saved.
+// A character that is percent-encoded with several octets counts once.
+func NormalizePercentEncodingWithStats(iri IRI) (IRI, Stats, error) {
+	var stats Stats
+	normalized, err := normalizeComponentsPercentEncoding(iri, isIUnreserved, &stats)
+	if err != nil {
+		return IRI{}, Stats{}, err
+	}
+	return normalized, stats, nil
+}
+
+// NormalizePercentEncodingKeeping works like NormalizePercentEncoding, yet never decodes
+// the given runes. This is useful for servers that require specific characters to stay
+// percent-encoded, even though they are unreserved.
+func NormalizePercentEncodingKeeping(iri IRI, keep []rune) (IRI, error) {
+	return normalizeComponentsPercentEncoding(iri, func(r rune) bool {
+		return isIUnreserved(r) && !strings.ContainsRune(string(keep), r)
+	}, nil)
+}
+
+// NormalizeSubDelims works like NormalizePercentEncoding, and additionally decodes percent-encoded
+// sub-delimiters, such as "%21" to "!", if decode is true. Otherwise, they stay percent-encoded,
+// which is the behaviour of NormalizePercentEncoding and Normalize.
+//
+// Sub-delimiters are reserved characters, and RFC 3986 Section 2.2 does not consider the encoded
+// and the decoded form equivalent. Decoding them is therefore an opt-in for applications that know
+// the semantics of their IRIs. In the query, the sub-delimiters "&", "=", "+", and ";" always stay
+// percent-encoded, as decoding them would change the key/value pairs of a form-encoded query.
+func NormalizeSubDelims(iri IRI, decode bool) (IRI, error) {
+	normalized, err := NormalizePercentEncoding(iri)
+	if (err != nil) || !decode {
+		return normalized, err
+	}
+	isDecodable := func(r rune) bool { return isIUnreserved(r) || isSubDelim(r) }
+	isDecodableInQuery := func(r rune) bool { return isDecodable(r) && !strings.ContainsRune("&=+;", r) }
+	query := normalized.Query
+	normalized.Query = ""
+	normalized, err = normalizeComponentsPercentEncoding(normalized, isDecodable, nil)
+	if err != nil {
+		return IRI{}, err
+	}
+	normalized.Query, err = normalizePercentEncoding(query, isDecodableInQuery, nil)
+	if err != nil {
+		return IRI{}, err
+	}
+	return normalized, nil
+}
+
+func normalizeComponentsPercentEncoding(iri IRI, isDecodable func(rune) bool, stats *Stats) (IRI, error) {
 	replaced := iri
 	var err error
-	replaced.Authority, err = normalizePercentEncoding(iri.Authority)
+	replaced.Authority, err = normalizePercentEncoding(iri.Authority, isDecodable, stats)
 	if err != nil {
 		return IRI{}, err
 	}
-	replaced.Path, err = normalizePercentEncoding(iri.Path)
+	replaced.Path, err = normalizePercentEncoding(iri.Path, isDecodable, stats)
 	if err != nil {
 		return IRI{}, err
 	}
-	replaced.Query, err = normalizePercentEncoding(iri.Query)
+	replaced.Query, err = normalizePercentEncoding(iri.Query, isDecodable, stats)
 	if err != nil {
 		return IRI{}, err
 	}
-	replaced.Fragment, err = normalizePercentEncoding(iri.Fragment)
+	replaced.Fragment, err = normalizePercentEncoding(iri.Fragment, isDecodable, stats)
 	if err != nil {
 		return IRI{}, err
 	}
 	return replaced, nil
 }
 
-// normalizePercentEncoding replaces unreserved percent-encoded characters with their equivalent.
+// normalizePercentEncoding replaces percent-encoded characters with their equivalent,
+// for which isDecodable returns true. If stats is not nil, the effect is added to it.
 //
 // Normalization background reading:
 // - https://blog.golang.org/normalization
 // - https://www.ietf.org/rfc/rfc3987.html#section-5
 //   - https://www.ietf.org/rfc/rfc3987.html#section-5.3.2.3 - percent encoding
-func normalizePercentEncoding(in string) (string, error) {
+func normalizePercentEncoding(in string, isDecodable func(rune) bool, stats *Stats) (string, error) {
 	var errs []error
 	replaced := pctEncodedCharOneOrMore.ReplaceAllStringFunc(in, func(pctEscaped string) string {
 		normalized := ""
@@ -153,9 +312,19 @@ func normalizePercentEncoding(in string) (string, error) {
 				errs = append(errs, fmt.Errorf("percent-encoded sequence %q contains invalid UTF-8 code point at start", pctEscaped[octetsOffset*3:]))
 				return pctEscaped
 			}
-			normalized += toUnreservedString(codePoint)
+			normalized += toUnreservedString(codePoint, isDecodable)
 			unconsumedOctets = unconsumedOctets[size:]
 			octetsOffset += size
+			if stats != nil {
+				if isDecodable(codePoint) {
+					stats.Decoded++
+				} else {
+					stats.Kept++
+				}
+			}
+		}
+		if stats != nil {
+			stats.BytesSaved += len(pctEscaped) - len(normalized)
 		}
 		return normalized
 	})
@@ -193,9 +362,8 @@ func octetsFrom(percentEncoded string) []byte {
 	return octets
 }
 
-func toUnreservedString(r rune) string {
-	isUnreserved := iunreservedRE.MatchString(string(r))
-	if isUnreserved {
+func toUnreservedString(r rune, isDecodable func(rune) bool) string {
+	if isDecodable(r) {
 		return string(r)
 	}
 	var percentEncoded string
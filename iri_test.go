@@ -1,6 +1,8 @@
 package iri_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/contomap/iri"
@@ -50,9 +52,10 @@ func TestParse(t *testing.T) {
 			want: "https://example.org?",
 		},
 		{
-			name: "ignored extra lines",
-			in:   "#f\nignored",
-			want: "#f",
+			name:    "line break in fragment",
+			in:      "#f\nignored",
+			want:    "",
+			wantErr: true,
 		},
 		{
 			// This is an "intentional" parse error; It is to showcase that examples from RFC 3987 with XML notation
@@ -82,6 +85,29 @@ func TestParse(t *testing.T) {
 			want:    "",
 			wantErr: true,
 		},
+		{
+			name:    "empty scheme before colon",
+			in:      ":",
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "empty scheme before colon with path",
+			in:      ":foo",
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "empty scheme before colon with authority",
+			in:      "://x",
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "colon after first segment of relative path",
+			in:   "./:foo",
+			want: "./:foo",
+		},
 		{
 			name:    "invalid authority",
 			in:      "//[not-a-v6]",
@@ -309,6 +335,14 @@ func TestNormalizePercentEncodingErrors(t *testing.T) {
 		{value: iri.IRI{Path: "%FF"}},
 		{value: iri.IRI{Query: "%FF"}},
 		{value: iri.IRI{Fragment: "%FF"}},
+		// Overlong encodings of '/' must not be decoded, as they could bypass path checks.
+		{value: iri.IRI{Path: "%C0%AF"}},
+		{value: iri.IRI{Path: "%E0%80%AF"}},
+		{value: iri.IRI{Path: "%F0%80%80%AF"}},
+		{value: iri.IRI{Path: "a%C0%AFb"}},
+		{value: iri.IRI{Query: "%C0%AF"}},
+		// UTF-16 surrogate halves are not valid in UTF-8.
+		{value: iri.IRI{Path: "%ED%A0%80"}},
 	}
 	t.Parallel()
 	for _, tc := range tt {
@@ -322,6 +356,45 @@ func TestNormalizePercentEncodingErrors(t *testing.T) {
 	}
 }
 
+func TestNormalizePercentEncodingKeepsReservedCharactersEncoded(t *testing.T) {
+	// The gen-delims and sub-delims of RFC 3986 Section 2.2, and the percent sign itself.
+	// Decoding any of them could change the structure or the meaning of the IRI.
+	const reserved = ":/?#[]@!$&'()*+,;=%"
+	components := []struct {
+		name  string
+		build func(encoded string) iri.IRI
+	}{
+		{name: "userinfo", build: func(encoded string) iri.IRI { return iri.IRI{Scheme: "s", Authority: "a" + encoded + "b@host"} }},
+		{name: "host", build: func(encoded string) iri.IRI { return iri.IRI{Scheme: "s", Authority: "a" + encoded + "b"} }},
+		{name: "path", build: func(encoded string) iri.IRI { return iri.IRI{Scheme: "s", Path: "/a" + encoded + "b"} }},
+		{name: "relative path", build: func(encoded string) iri.IRI { return iri.IRI{Path: "a" + encoded + "b"} }},
+		{name: "query", build: func(encoded string) iri.IRI { return iri.IRI{Scheme: "s", Path: "/", Query: "a" + encoded + "b"} }},
+		{name: "fragment", build: func(encoded string) iri.IRI { return iri.IRI{Scheme: "s", Path: "/", Fragment: "a" + encoded + "b"} }},
+	}
+	t.Parallel()
+	for _, component := range components {
+		component := component
+		for _, r := range reserved {
+			for _, encoded := range []string{fmt.Sprintf("%%%02X", r), fmt.Sprintf("%%%02x", r)} {
+				in := component.build(encoded)
+				t.Run(component.name+" "+encoded, func(t *testing.T) {
+					t.Parallel()
+					got, err := iri.NormalizePercentEncoding(in)
+					if err != nil {
+						t.Fatalf("NormalizePercentEncoding() returned error: %v", err)
+					}
+					if want := iri.UppercasePercentHex(in); got != want {
+						t.Errorf("NormalizePercentEncoding(%q) = %q, want %q", in, got, want)
+					}
+					if parsed, err := iri.Parse(got.String()); (err != nil) || (parsed != got) {
+						t.Errorf("Parse(%q) = %#v, %v, want %#v", got, parsed, err, got)
+					}
+				})
+			}
+		}
+	}
+}
+
 func TestResolveReferenceManualSamples(t *testing.T) {
 	// Many test cases here duplicate the samples from the RFCs, yet they are kept here as a manual test basis.
 	tt := []struct {
@@ -702,3 +775,560 @@ func allOf(list ...verifyFunc) verifyFunc {
 		}
 	}
 }
+
+func TestResolveReferenceStrict(t *testing.T) {
+	tt := []struct {
+		name      string
+		base, ref string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "absolute base",
+			base: "https://example.com/sub/path/testing#frag1",
+			ref:  "../other",
+			want: "https://example.com/sub/other",
+		},
+		{
+			name:    "empty base",
+			base:    "",
+			ref:     "",
+			wantErr: true,
+		},
+		{
+			name:    "network-path base",
+			base:    "//example.com/sub/path",
+			ref:     "other",
+			wantErr: true,
+		},
+		{
+			name:    "opaque base with relative-path reference",
+			base:    "urn:foo:bar",
+			ref:     "baz",
+			wantErr: true,
+		},
+		{
+			name:    "opaque base with absolute-path reference",
+			base:    "urn:foo:bar",
+			ref:     "/baz",
+			wantErr: true,
+		},
+		{
+			name: "opaque base with fragment reference",
+			base: "urn:foo:bar",
+			ref:  "#frag",
+			want: "urn:foo:bar#frag",
+		},
+		{
+			name: "opaque base with absolute reference",
+			base: "urn:foo:bar",
+			ref:  "mailto:x@y",
+			want: "mailto:x@y",
+		},
+		{
+			name:    "relative base with absolute reference",
+			base:    "sub/path",
+			ref:     "https://example.com",
+			wantErr: true,
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			base, err := iri.Parse(tc.base)
+			if err != nil {
+				t.Errorf("base IRI %q is not a valid IRI: %v", tc.base, err)
+			}
+			ref, err := iri.Parse(tc.ref)
+			if err != nil {
+				t.Errorf("ref IRI %q is not a valid IRI: %v", tc.ref, err)
+			}
+			got, err := base.ResolveReferenceStrict(ref)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("ResolveReferenceStrict(%q, %q)\n  got %q\n want %q", tc.base, tc.ref, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseDistinguishesEmptyPathFromSlashPath(t *testing.T) {
+	tt := []struct {
+		in       string
+		wantPath string
+	}{
+		{in: "http://host", wantPath: ""},
+		{in: "http://host/", wantPath: "/"},
+		{in: "http://host?q", wantPath: ""},
+		{in: "http://host/?q", wantPath: "/"},
+		{in: "http://host#f", wantPath: ""},
+		{in: "http://host/#f", wantPath: "/"},
+		{in: "foo:", wantPath: ""},
+		{in: "foo:/", wantPath: "/"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got.Path != tc.wantPath {
+				t.Errorf("Parse(%q).Path = %q, want %q", tc.in, got.Path, tc.wantPath)
+			}
+			if got.String() != tc.in {
+				t.Errorf("Parse().String() roundtrip failed: input: %q, output: %q", tc.in, got)
+			}
+		})
+	}
+}
+
+func TestParseIPv4Hosts(t *testing.T) {
+	tt := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: "//1.2.3.4"},
+		{in: "//[::1.2.3.4]"},
+		{in: "//[::1x2x3x4]", wantErr: true},
+		{in: "//[::999.1.1.1]", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			_, err := iri.Parse(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseIPv6Hosts(t *testing.T) {
+	tt := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: "//[::]"},
+		{in: "//[::1]"},
+		{in: "//[1:2:3:4:5:6:7:8]"},
+		{in: "//[1::8]"},
+		{in: "//[1::2::3]", wantErr: true},
+		{in: "//[::1::2]", wantErr: true},
+		{in: "//[1:2:3:4:5:6:7:8:9]", wantErr: true},
+		{in: "//[1:2:3:4:5:6:7::8:9]", wantErr: true},
+		{in: "//[1:2:3:4:5:6:7]", wantErr: true},
+		{in: "//[:::]", wantErr: true},
+		{in: "//[12345::1]", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			_, err := iri.Parse(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if (err != nil) && !strings.Contains(err.Error(), "invalid IP literal") {
+				t.Errorf("got err %v, want it to report an invalid IP literal", err)
+			}
+		})
+	}
+}
+
+func TestParseInvalidAuthorityWithValidIPLiteral(t *testing.T) {
+	tt := []string{
+		"//[::1]:8a",
+		"//u^@[::1]",
+		"http://u^@[::1]:80/",
+		"//[v7.x]:-1",
+	}
+	t.Parallel()
+	for _, in := range tt {
+		in := in
+		t.Run(in, func(t *testing.T) {
+			t.Parallel()
+			_, err := iri.Parse(in)
+			if err == nil {
+				t.Fatalf("Parse() returned no error")
+			}
+			if strings.Contains(err.Error(), "invalid IP literal") || !strings.Contains(err.Error(), "invalid authority") {
+				t.Errorf("got err %v, want it to report an invalid authority", err)
+			}
+		})
+	}
+}
+
+func TestParseEmptyAuthorityWithSlashes(t *testing.T) {
+	tt := []struct {
+		in   string
+		want iri.IRI
+	}{
+		{in: "//", want: iri.IRI{ForceAuthority: true}},
+		{in: "///", want: iri.IRI{ForceAuthority: true, Path: "/"}},
+		{in: "////", want: iri.IRI{ForceAuthority: true, Path: "//"}},
+		{in: "file:///", want: iri.IRI{Scheme: "file", ForceAuthority: true, Path: "/"}},
+		{in: "file:////server/share", want: iri.IRI{Scheme: "file", ForceAuthority: true, Path: "//server/share"}},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Parse(%q) = %#v, want %#v", tc.in, got, tc.want)
+			}
+			if got.String() != tc.in {
+				t.Errorf("Parse().String() roundtrip failed: input: %q, output: %q", tc.in, got)
+			}
+		})
+	}
+}
+
+func TestParseInto(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    iri.IRI
+		wantErr bool
+	}{
+		{in: "https://example.com/a?b#c", want: iri.IRI{Scheme: "https", Authority: "example.com", Path: "/a", Query: "b", Fragment: "c"}},
+		{in: "//", want: iri.IRI{ForceAuthority: true}},
+		{in: "", want: iri.IRI{}},
+		{in: "https://example.com/%zz", wantErr: true},
+		{in: ":a", wantErr: true},
+	}
+	previous := iri.IRI{Scheme: "urn", Path: "previous", ForceQuery: true}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			target := previous
+			err := target.ParseInto(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			want := tc.want
+			if tc.wantErr {
+				want = previous
+			}
+			if target != want {
+				t.Errorf("ParseInto(%q) set %#v, want %#v", tc.in, target, want)
+			}
+		})
+	}
+}
+
+func BenchmarkParseInto(b *testing.B) {
+	inputs := []string{
+		"https://example.com/a/b/c?q=1#f",
+		"http://user@[2001:db8::1]:8080/µ/€?ü",
+		"urn:isbn:0451450523",
+		"../relative/path",
+	}
+	b.Run("Parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, in := range inputs {
+				if _, err := iri.Parse(in); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("ParseInto", func(b *testing.B) {
+		b.ReportAllocs()
+		var target iri.IRI
+		for i := 0; i < b.N; i++ {
+			for _, in := range inputs {
+				if err := target.ParseInto(in); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+func TestParseStringIsByteExact(t *testing.T) {
+	// Parse keeps the original percent-encoding, including the case of its hexadecimal digits,
+	// and sets the Force* flags whenever a delimiter of an empty component is present.
+	// As a result, String returns exactly the parsed input.
+	// This does not hold if parse options are used that modify components, such as LowercaseScheme.
+	tt := []struct {
+		value string
+	}{
+		{"https://example.com/%7e%7E/%2f%2F?%c2%B5=%C2%b5#%41"},
+		{"https://user:pw@example.com:/a//b/./../c?#"},
+		{"HTTPS://EXAMPLE.COM:443"},
+		{"//"},
+		{"///"},
+		{"?"},
+		{"#"},
+		{"//?#"},
+		{"a:"},
+		{"a:b:c"},
+		{"urn:uuid:6c689097-8097-4421-9def-05e835f2dbb8"},
+		{"https://[2001:DB8::1]:8080/µ?€#Ῥόδος"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.value)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got.String() != tc.value {
+				t.Errorf("Parse().String() roundtrip failed:\n  input:  %q\n  output: %q", tc.value, got)
+			}
+		})
+	}
+}
+
+func TestNormalizePercentEncodingKeeping(t *testing.T) {
+	tt := []struct {
+		name string
+		in   string
+		keep []rune
+		want string
+	}{
+		{
+			name: "keep tilde",
+			in:   "https://example.com/%7euser/%2e%2E/%c2%b5?%7E=%41#%7E",
+			keep: []rune{'~'},
+			want: "https://example.com/%7Euser/../µ?%7E=A#%7E",
+		},
+		{
+			name: "keep several",
+			in:   "https://example.com/%7e%2e%41%C2%B5",
+			keep: []rune{'.', 'µ', '~'},
+			want: "https://example.com/%7E%2EA%C2%B5",
+		},
+		{
+			name: "keep nothing",
+			in:   "https://example.com/%7e%2e",
+			keep: nil,
+			want: "https://example.com/~.",
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			in, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("IRI %q is not a valid IRI: %v", tc.in, err)
+			}
+			got, err := iri.NormalizePercentEncodingKeeping(in, tc.keep)
+			if err != nil {
+				t.Fatalf("NormalizePercentEncodingKeeping() returned error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("NormalizePercentEncodingKeeping(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStringEncodingSpaces(t *testing.T) {
+	tt := []struct {
+		in   iri.IRI
+		want string
+	}{
+		{in: iri.IRI{Path: "/a b"}, want: "/a%20b"},
+		{in: iri.IRI{Scheme: "https", Authority: "example.com", Path: "/a b/ c", Query: "q=a b", Fragment: " f"}, want: "https://example.com/a%20b/%20c?q=a%20b#%20f"},
+		{in: iri.IRI{Scheme: "https", Authority: "example.com", Path: "/a%20b", Query: "q=+"}, want: "https://example.com/a%20b?q=+"},
+		{in: iri.IRI{ForceAuthority: true, ForceQuery: true, ForceFragment: true}, want: "//?#"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+			got := tc.in.StringEncodingSpaces()
+			if got != tc.want {
+				t.Errorf("StringEncodingSpaces() = %q, want %q", got, tc.want)
+			}
+			if _, err := iri.Parse(got); err != nil {
+				t.Errorf("Parse(%q) returned error: %v", got, err)
+			}
+		})
+	}
+}
+
+func TestParseEmptySchemeError(t *testing.T) {
+	t.Parallel()
+	_, err := iri.Parse(":foo")
+	if err == nil || !strings.Contains(err.Error(), "empty scheme before colon") {
+		t.Errorf("Parse(%q) got err %v, want an error about the empty scheme", ":foo", err)
+	}
+}
+
+func TestForceFlags(t *testing.T) {
+	tt := []struct {
+		name  string
+		value iri.IRI
+		want  string
+	}{
+		{name: "authority without flag", value: iri.IRI{Authority: "x"}, want: "//x"},
+		{name: "authority with flag", value: iri.IRI{Authority: "x", ForceAuthority: true}, want: "//x"},
+		{name: "empty authority without flag", value: iri.IRI{Path: "/a"}, want: "/a"},
+		{name: "empty authority with flag", value: iri.IRI{ForceAuthority: true, Path: "/a"}, want: "///a"},
+		{name: "query without flag", value: iri.IRI{Query: "q"}, want: "?q"},
+		{name: "query with flag", value: iri.IRI{Query: "q", ForceQuery: true}, want: "?q"},
+		{name: "empty query with flag", value: iri.IRI{ForceQuery: true}, want: "?"},
+		{name: "fragment without flag", value: iri.IRI{Fragment: "f"}, want: "#f"},
+		{name: "fragment with flag", value: iri.IRI{Fragment: "f", ForceFragment: true}, want: "#f"},
+		{name: "empty fragment with flag", value: iri.IRI{ForceFragment: true}, want: "#"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := tc.value.String()
+			if got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+			parsed, err := iri.Parse(got)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", got, err)
+			}
+			if parsed.String() != got {
+				t.Errorf("Parse(%q).String() = %q", got, parsed)
+			}
+		})
+	}
+}
+
+func TestParseSetsForceFlagsOnlyForEmptyComponents(t *testing.T) {
+	tt := []struct {
+		in   string
+		want iri.IRI
+	}{
+		{in: "//x", want: iri.IRI{Authority: "x"}},
+		{in: "//", want: iri.IRI{ForceAuthority: true}},
+		{in: "http:///a", want: iri.IRI{Scheme: "http", ForceAuthority: true, Path: "/a"}},
+		{in: "?q#f", want: iri.IRI{Query: "q", Fragment: "f"}},
+		{in: "?#", want: iri.IRI{ForceQuery: true, ForceFragment: true}},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Parse(%q) = %#v, want %#v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePercentEncodingWithStats(t *testing.T) {
+	tt := []struct {
+		name      string
+		in        iri.IRI
+		want      string
+		wantStats iri.Stats
+		wantErr   bool
+	}{
+		{
+			name:      "nothing encoded",
+			in:        iri.IRI{Scheme: "http", Authority: "example.com", Path: "/a"},
+			want:      "http://example.com/a",
+			wantStats: iri.Stats{},
+		},
+		{
+			name:      "mixed",
+			in:        iri.IRI{Scheme: "http", Authority: "%65xample.com", Path: "/%c2%b5/%20/%2F", Query: "q=%7E", Fragment: "%41%42"},
+			want:      "http://example.com/µ/%20/%2F?q=~#AB",
+			wantStats: iri.Stats{Decoded: 5, Kept: 2, BytesSaved: 2 + 4 + 2 + 4},
+		},
+		{
+			name:      "kept with lowercase hex",
+			in:        iri.IRI{Path: "%2f"},
+			want:      "%2F",
+			wantStats: iri.Stats{Kept: 1},
+		},
+		{
+			name:    "invalid",
+			in:      iri.IRI{Path: "%41%FF"},
+			wantErr: true,
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, stats, err := iri.NormalizePercentEncodingWithStats(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("NormalizePercentEncodingWithStats() = %q, want %q", got, tc.want)
+			}
+			if stats != tc.wantStats {
+				t.Errorf("NormalizePercentEncodingWithStats() stats = %+v, want %+v", stats, tc.wantStats)
+			}
+		})
+	}
+}
+
+func TestResolveReferenceOpaqueBase(t *testing.T) {
+	tt := []struct {
+		base, ref string
+		want      string
+	}{
+		{base: "urn:foo:bar", ref: "baz", want: "urn:foo:bar"},
+		{base: "urn:foo:bar", ref: "../baz", want: "urn:foo:bar"},
+		{base: "urn:foo:bar", ref: "/baz", want: "urn:foo:bar"},
+		{base: "urn:foo:bar#f", ref: "baz", want: "urn:foo:bar"},
+		{base: "urn:a:b#x", ref: "baz?q#f", want: "urn:a:b"},
+		{base: "urn:a:b?q#x", ref: "baz#f", want: "urn:a:b?q"},
+		{base: "urn:a:b#", ref: "baz", want: "urn:a:b"},
+		{base: "urn:foo:bar?q", ref: "", want: "urn:foo:bar?q"},
+		{base: "urn:foo:bar?q", ref: "#frag", want: "urn:foo:bar?q#frag"},
+		{base: "urn:foo:bar?q", ref: "?y", want: "urn:foo:bar?y"},
+		{base: "urn:foo:./bar", ref: "#frag", want: "urn:foo:./bar#frag"},
+		{base: "mailto:a@example.com", ref: "mailto:x@y", want: "mailto:x@y"},
+		{base: "mailto:a@example.com", ref: "//example.com/p", want: "mailto://example.com/p"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.base+" "+tc.ref, func(t *testing.T) {
+			t.Parallel()
+			base, errBase := iri.Parse(tc.base)
+			ref, errRef := iri.Parse(tc.ref)
+			if (errBase != nil) || (errRef != nil) {
+				t.Fatalf("Parse() returned errors: %v, %v", errBase, errRef)
+			}
+			if got := base.ResolveReference(ref); got.String() != tc.want {
+				t.Errorf("ResolveReference(%q, %q) = %q, want %q", tc.base, tc.ref, got, tc.want)
+			}
+			if got := base.ResolveAll([]iri.IRI{ref}); got[0].String() != tc.want {
+				t.Errorf("ResolveAll(%q, %q) = %q, want %q", tc.base, tc.ref, got[0], tc.want)
+			}
+		})
+	}
+}
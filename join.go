@@ -0,0 +1,24 @@
+package iri
+
+import "fmt"
+
+// Join parses the base and the reference, resolves the reference against the base,
+// and returns the normalized result.
+//
+// The base must be an absolute IRI, see IRI.ResolveReferenceStrict.
+// The returned error states whether the base or the reference is invalid.
+func Join(base, ref string) (IRI, error) {
+	baseIRI, err := Parse(base)
+	if err != nil {
+		return IRI{}, fmt.Errorf("invalid base: %w", err)
+	}
+	refIRI, err := Parse(ref)
+	if err != nil {
+		return IRI{}, fmt.Errorf("invalid reference: %w", err)
+	}
+	resolved, err := baseIRI.ResolveReferenceStrict(refIRI)
+	if err != nil {
+		return IRI{}, fmt.Errorf("invalid base: %w", err)
+	}
+	return resolved.Normalize()
+}
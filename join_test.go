@@ -0,0 +1,86 @@
+package iri_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestJoinRFC3986Samples(t *testing.T) {
+	tt := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "g:h", want: "g:h"},
+		{ref: "g", want: "http://a/b/c/g"},
+		{ref: "./g", want: "http://a/b/c/g"},
+		{ref: "g/", want: "http://a/b/c/g/"},
+		{ref: "/g", want: "http://a/g"},
+		{ref: "//g", want: "http://g"},
+		{ref: "?y", want: "http://a/b/c/d;p?y"},
+		{ref: "g?y", want: "http://a/b/c/g?y"},
+		{ref: "#s", want: "http://a/b/c/d;p?q#s"},
+		{ref: "g#s", want: "http://a/b/c/g#s"},
+		{ref: "g?y#s", want: "http://a/b/c/g?y#s"},
+		{ref: ";x", want: "http://a/b/c/;x"},
+		{ref: "g;x", want: "http://a/b/c/g;x"},
+		{ref: "g;x?y#s", want: "http://a/b/c/g;x?y#s"},
+		{ref: "", want: "http://a/b/c/d;p?q"},
+		{ref: ".", want: "http://a/b/c/"},
+		{ref: "./", want: "http://a/b/c/"},
+		{ref: "..", want: "http://a/b/"},
+		{ref: "../", want: "http://a/b/"},
+		{ref: "../g", want: "http://a/b/g"},
+		{ref: "../..", want: "http://a/"},
+		{ref: "../../", want: "http://a/"},
+		{ref: "../../g", want: "http://a/g"},
+		{ref: "../../../g", want: "http://a/g"},
+		{ref: "/./g", want: "http://a/g"},
+		{ref: "g;x=1/../y", want: "http://a/b/c/y"},
+		{ref: "g?y/./x", want: "http://a/b/c/g?y/./x"},
+		{ref: "g#s/../x", want: "http://a/b/c/g#s/../x"},
+		{ref: "%67", want: "http://a/b/c/g"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.ref, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Join("http://a/b/c/d;p?q", tc.ref)
+			if err != nil {
+				t.Fatalf("Join() returned error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("Join(%q) = %q, want %q", tc.ref, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	tt := []struct {
+		name       string
+		base, ref  string
+		wantPrefix string
+	}{
+		{name: "invalid base", base: "http://a/ b", ref: "g", wantPrefix: "invalid base:"},
+		{name: "relative base", base: "/a/b", ref: "g", wantPrefix: "invalid base:"},
+		{name: "invalid reference", base: "http://a/b", ref: "g h", wantPrefix: "invalid reference:"},
+		{name: "invalid percent-encoding in reference", base: "http://a/b", ref: "%FF", wantPrefix: "invalid reference:"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := iri.Join(tc.base, tc.ref)
+			if err == nil {
+				t.Fatalf("Join() did not return an error")
+			}
+			if !strings.HasPrefix(err.Error(), tc.wantPrefix) {
+				t.Errorf("Join() error %q does not start with %q", err, tc.wantPrefix)
+			}
+		})
+	}
+}
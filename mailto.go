@@ -0,0 +1,97 @@
+package iri
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Mailto creates a "mailto" IRI for the given addresses and header fields, as per RFC 6068.
+//
+// Addresses are separated by commas, and the header fields form the query, sorted by their name.
+// Addresses and header fields are percent-encoded where necessary, which includes the characters
+// "?", "&", "=", "#", "%", and space. Commas are encoded in addresses only. Other characters,
+// such as "@" and "+", are kept so that addresses stay readable.
+//
+// It returns an error if an address or a header field name is empty.
+// See https://www.rfc-editor.org/rfc/rfc6068.
+func Mailto(addresses []string, headers map[string]string) (IRI, error) {
+	escapedAddresses := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if address == "" {
+			return IRI{}, fmt.Errorf("empty address in mailto IRI")
+		}
+		escapedAddresses = append(escapedAddresses, escapeString(address, func(r rune) bool {
+			return isMailtoChar(r) && (r != ',')
+		}))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		if name == "" {
+			return IRI{}, fmt.Errorf("empty header field name in mailto IRI")
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fields := make([]string, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, escapeString(name, isMailtoChar)+"="+escapeString(headers[name], isMailtoChar))
+	}
+	result := IRI{
+		Scheme: "mailto",
+		Path:   strings.Join(escapedAddresses, ","),
+		Query:  strings.Join(fields, "&"),
+	}
+	if err := result.Validate(); err != nil {
+		return IRI{}, err
+	}
+	return result, nil
+}
+
+// isMailtoChar returns true for the characters of "qchar" of RFC 6068, extended by the
+// non-ASCII characters of IRIs. These need no percent-encoding in addresses and header fields.
+func isMailtoChar(r rune) bool {
+	return isIUnreserved(r) || strings.ContainsRune("!$'()*+,;:@", r)
+}
+
+// MailtoParts returns the addresses and header fields of a "mailto" IRI, as per RFC 6068.
+// It is the counterpart to Mailto.
+//
+// The path is split at commas into addresses, and the query is split into header fields.
+// All parts are percent-decoded; A plus sign ('+') is kept literally.
+// Should a header field name occur several times, the last value is returned.
+//
+// It returns an error if the scheme is not "mailto" or if any part contains invalid percent-encoding.
+func (iri IRI) MailtoParts() (addresses []string, headers map[string]string, err error) {
+	if !strings.EqualFold(iri.Scheme, "mailto") {
+		return nil, nil, fmt.Errorf("%q is not a mailto IRI", iri)
+	}
+	if iri.Path != "" {
+		for _, rawAddress := range strings.Split(iri.Path, ",") {
+			address, err := Unescape(rawAddress)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid address %q: %w", rawAddress, err)
+			}
+			addresses = append(addresses, address)
+		}
+	}
+	headers = map[string]string{}
+	if iri.Query != "" {
+		for _, field := range strings.Split(iri.Query, "&") {
+			if field == "" {
+				continue
+			}
+			rawName, rawValue, _ := strings.Cut(field, "=")
+			name, err := Unescape(rawName)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid header field name %q: %w", rawName, err)
+			}
+			value, err := Unescape(rawValue)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid header field value %q: %w", rawValue, err)
+			}
+			headers[name] = value
+		}
+	}
+	return addresses, headers, nil
+}
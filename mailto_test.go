@@ -0,0 +1,162 @@
+package iri_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestMailto(t *testing.T) {
+	tt := []struct {
+		name      string
+		addresses []string
+		headers   map[string]string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "single address",
+			addresses: []string{"John.Doe@example.com"},
+			want:      "mailto:John.Doe@example.com",
+		},
+		{
+			name:      "multiple recipients with subject",
+			addresses: []string{"a@example.com", "b+tag@example.org"},
+			headers:   map[string]string{"subject": "Hello there", "body": "Line?a&b=c#d 100%"},
+			want:      "mailto:a@example.com,b+tag@example.org?body=Line%3Fa%26b%3Dc%23d%20100%25&subject=Hello%20there",
+		},
+		{
+			name:      "comma in quoted address",
+			addresses: []string{`"Doe, John"@example.com`},
+			want:      "mailto:%22Doe%2C%20John%22@example.com",
+		},
+		{
+			name:      "non-ASCII characters are kept",
+			addresses: []string{"user@bücher.example"},
+			headers:   map[string]string{"subject": "Grüße"},
+			want:      "mailto:user@bücher.example?subject=Grüße",
+		},
+		{
+			name:    "headers only",
+			headers: map[string]string{"to": "a@example.com"},
+			want:    "mailto:?to=a@example.com",
+		},
+		{
+			name:      "empty address",
+			addresses: []string{"a@example.com", ""},
+			wantErr:   true,
+		},
+		{
+			name:      "empty header name",
+			addresses: []string{"a@example.com"},
+			headers:   map[string]string{"": "value"},
+			wantErr:   true,
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Mailto(tc.addresses, tc.headers)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("Mailto() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMailtoParts(t *testing.T) {
+	tt := []struct {
+		in            string
+		wantAddresses []string
+		wantHeaders   map[string]string
+		wantErr       bool
+	}{
+		{
+			in:            "mailto:John.Doe@example.com",
+			wantAddresses: []string{"John.Doe@example.com"},
+			wantHeaders:   map[string]string{},
+		},
+		{
+			in:            "mailto:a@example.com,b+tag@example.org?subject=Hello%20there&body=a%26b",
+			wantAddresses: []string{"a@example.com", "b+tag@example.org"},
+			wantHeaders:   map[string]string{"subject": "Hello there", "body": "a&b"},
+		},
+		{
+			in:            "MAILTO:%22Doe%2C%20John%22@example.com",
+			wantAddresses: []string{`"Doe, John"@example.com`},
+			wantHeaders:   map[string]string{},
+		},
+		{
+			in:          "mailto:?to=a@example.com&&cc",
+			wantHeaders: map[string]string{"to": "a@example.com", "cc": ""},
+		},
+		{
+			in:      "https://example.com",
+			wantErr: true,
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			addresses, headers, err := value.MailtoParts()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(addresses, tc.wantAddresses) {
+				t.Errorf("MailtoParts() addresses got %q, want %q", addresses, tc.wantAddresses)
+			}
+			if !reflect.DeepEqual(headers, tc.wantHeaders) {
+				t.Errorf("MailtoParts() headers got %q, want %q", headers, tc.wantHeaders)
+			}
+		})
+	}
+}
+
+func TestMailtoPartsErrors(t *testing.T) {
+	tt := []struct {
+		value iri.IRI
+	}{
+		{value: iri.IRI{Scheme: "mailto", Path: "a%C0%AF@example.com"}},
+		{value: iri.IRI{Scheme: "mailto", Path: "a@example.com", Query: "subject=%C0%AF"}},
+		{value: iri.IRI{Scheme: "mailto", Path: "a@example.com", Query: "%2=value"}},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.value.String(), func(t *testing.T) {
+			t.Parallel()
+			if _, _, err := tc.value.MailtoParts(); err == nil {
+				t.Errorf("MailtoParts() did not return an error")
+			}
+		})
+	}
+}
+
+func TestMailtoRoundTrip(t *testing.T) {
+	t.Parallel()
+	addresses := []string{`"Doe, John"@example.com`, "b+tag@example.org"}
+	headers := map[string]string{"subject": "Hi & bye = 100%", "body": "a?b#c"}
+	built, err := iri.Mailto(addresses, headers)
+	if err != nil {
+		t.Fatalf("Mailto() returned error: %v", err)
+	}
+	gotAddresses, gotHeaders, err := built.MailtoParts()
+	if err != nil {
+		t.Fatalf("MailtoParts() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(gotAddresses, addresses) || !reflect.DeepEqual(gotHeaders, headers) {
+		t.Errorf("round trip of %q got %q, %q", built, gotAddresses, gotHeaders)
+	}
+}
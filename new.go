@@ -0,0 +1,24 @@
+package iri
+
+// New creates an IRI from unescaped components.
+//
+// Each component is escaped with the respective Escape function, and the resulting IRI is validated.
+// This is the counterpart to Parse: Parse expects a string in which all components are already escaped,
+// while New expects the components as raw text. Passing already escaped text to New encodes
+// any percent sign ('%') again.
+//
+// The scheme is not escaped, as it cannot contain escaped characters.
+// An empty component is considered absent.
+func New(scheme, authority, path, query, fragment string) (IRI, error) {
+	result := IRI{
+		Scheme:    scheme,
+		Authority: EscapeAuthority(authority),
+		Path:      EscapePath(path),
+		Query:     EscapeQuery(query),
+		Fragment:  EscapeFragment(fragment),
+	}
+	if err := result.Validate(); err != nil {
+		return IRI{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestNew(t *testing.T) {
+	tt := []struct {
+		name                                     string
+		scheme, authority, path, query, fragment string
+		want                                     string
+		wantErr                                  bool
+	}{
+		{
+			name:   "space in path and ampersand in query",
+			scheme: "https", authority: "example.com", path: "/a b", query: "x=1&y=2 3",
+			want: "https://example.com/a%20b?x=1&y=2%203",
+		},
+		{
+			name:   "percent sign is escaped",
+			scheme: "https", authority: "example.com", path: "/100%", fragment: "50%25",
+			want: "https://example.com/100%25#50%2525",
+		},
+		{
+			name:   "non-ASCII is kept",
+			scheme: "https", authority: "user@é.example:8080", path: "/µ", query: "q=€", fragment: "Ῥόδος",
+			want: "https://user@é.example:8080/µ?q=€#Ῥόδος",
+		},
+		{
+			name: "fragment delimiters",
+			path: "a#b", query: "c#d", fragment: "e#f",
+			want: "a%23b?c%23d#e%23f",
+		},
+		{
+			name:   "invalid scheme",
+			scheme: "1 x", path: "/a",
+			wantErr: true,
+		},
+		{
+			name:      "rootless path with authority",
+			authority: "example.com", path: "a",
+			wantErr: true,
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.New(tc.scheme, tc.authority, tc.path, tc.query, tc.fragment)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("New() = %q, want %q", got, tc.want)
+			}
+			if _, err := iri.Parse(got.String()); err != nil {
+				t.Errorf("result is not a valid IRI: %v", err)
+			}
+		})
+	}
+}
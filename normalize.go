@@ -0,0 +1,162 @@
+package iri
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// Normalize returns an IRI that has the syntax-based normalization of RFC 3987 applied.
+//
+// This covers, in this order:
+//   - percent-encoding normalization, see NormalizePercentEncoding;
+//   - case normalization, see IRI.NormalizeCase;
+//   - path segment normalization, for IRIs with a scheme and an absolute path.
+//
+// Percent-encoded sub-delimiters, such as "%26", stay encoded, as they are reserved characters.
+// See NormalizeSubDelims to decode them.
+//
+// The Force* flags are kept as they are.
+// If percent-encoding normalization fails, this function returns an error and an empty IRI.
+//
+// See https://www.ietf.org/rfc/rfc3987.html#section-5.3.2.
+func (iri IRI) Normalize() (IRI, error) {
+	decoded, err := NormalizePercentEncoding(iri)
+	if err != nil {
+		return IRI{}, err
+	}
+	return decoded.normalizeSyntax(), nil
+}
+
+// NormalizeCase returns an IRI with a lowercase scheme and host,
+// and with uppercase hexadecimal digits in all percent-encoded octets.
+// Percent-encoded octets are never decoded by this method.
+//
+// See https://www.ietf.org/rfc/rfc3987.html#section-5.3.2.1.
+func (iri IRI) NormalizeCase() IRI {
+	return iri.normalizeCase(true)
+}
+
+// normalizeCase applies case normalization; The host is only made lowercase if foldHost is set.
+func (iri IRI) normalizeCase(foldHost bool) IRI {
+	normalized := iri
+	normalized.Scheme = strings.ToLower(iri.Scheme)
+	if foldHost && (iri.Authority != "") {
+		parts := splitAuthority(iri.Authority)
+		parts.host = lowercaseHost(parts.host)
+		normalized.Authority = parts.String()
+	}
+	normalized.Authority = uppercasePercentHex(normalized.Authority)
+	normalized.Path = uppercasePercentHex(iri.Path)
+	normalized.Query = uppercasePercentHex(iri.Query)
+	normalized.Fragment = uppercasePercentHex(iri.Fragment)
+	return normalized
+}
+
+// CanonicalString returns the string of the normalized IRI.
+// Should the IRI contain invalid percent-encoding, the remaining normalization
+// steps are still applied, leaving the percent-encoding as is.
+func (iri IRI) CanonicalString() string {
+	normalized, err := iri.Normalize()
+	if err != nil {
+		return iri.normalizeSyntax().String()
+	}
+	return normalized.String()
+}
+
+// EqualNormalized returns true if both IRIs are equal after normalization.
+// This is equivalent to comparing their canonical strings.
+func EqualNormalized(a, b IRI) bool {
+	return a.CanonicalString() == b.CanonicalString()
+}
+
+func (iri IRI) normalizeSyntax() IRI {
+	return iri.normalizeSyntaxWith(true)
+}
+
+// normalizeSyntaxWith applies case and path segment normalization; See IRI.normalizeCase for foldHost.
+func (iri IRI) normalizeSyntaxWith(foldHost bool) IRI {
+	normalized := iri.normalizeCase(foldHost)
+	if normalized.hasScheme() && strings.HasPrefix(normalized.Path, "/") {
+		normalized.Path = resolvePath(normalized.Path, "")
+	}
+	return normalized
+}
+
+// UppercasePercentHex returns an IRI with uppercase hexadecimal digits in all percent-encoded octets,
+// such as "%2F" instead of "%2f". Nothing else is changed; In particular, no octets are decoded.
+//
+// This is a cheap part of case normalization, which suffices if the IRIs to compare
+// are known to be otherwise normalized.
+// See https://www.rfc-editor.org/rfc/rfc3986#section-6.2.2.1.
+func UppercasePercentHex(iri IRI) IRI {
+	result := iri
+	result.Authority = uppercasePercentHex(iri.Authority)
+	result.Path = uppercasePercentHex(iri.Path)
+	result.Query = uppercasePercentHex(iri.Query)
+	result.Fragment = uppercasePercentHex(iri.Fragment)
+	return result
+}
+
+func uppercasePercentHex(s string) string {
+	return pctEncodedCharOneOrMore.ReplaceAllStringFunc(s, strings.ToUpper)
+}
+
+// NormalizeHost returns an IRI with a lowercase scheme and a normalized host.
+//
+// The host is normalized in a fixed order: first, unnecessarily percent-encoded
+// octets are decoded; Then, the resulting characters are case-folded to lowercase;
+// Finally, the hexadecimal digits of remaining percent-encoded octets are made uppercase.
+// This way, percent-encoded octets are never case-folded as text.
+// Should the host contain invalid percent-encoding, it is not decoded.
+//
+// Userinfo, port, and all other components are kept as they are.
+func (iri IRI) NormalizeHost() IRI {
+	normalized := iri
+	normalized.Scheme = strings.ToLower(iri.Scheme)
+	if iri.Authority == "" {
+		return normalized
+	}
+	parts := splitAuthority(iri.Authority)
+	if decoded, err := normalizePercentEncoding(parts.host, isIUnreserved, nil); err == nil {
+		parts.host = decoded
+	}
+	parts.host = uppercasePercentHex(lowercaseHost(parts.host))
+	normalized.Authority = parts.String()
+	return normalized
+}
+
+// NormalizeIPv6 returns an IRI with a bracketed IPv6 host rewritten to the canonical text
+// representation of RFC 5952: lowercase hexadecimal digits without leading zeros, and
+// the longest run of zero groups compressed to "::".
+// A zone identifier is kept as it is.
+//
+// Hosts of other types, as well as all other components, are kept as they are.
+func (iri IRI) NormalizeIPv6() IRI {
+	if iri.HostType() != HostTypeIPv6 {
+		return iri
+	}
+	parts := splitAuthority(iri.Authority)
+	address, zone, hasZone := strings.Cut(parts.host[1:len(parts.host)-1], "%25")
+	ip, err := netip.ParseAddr(address)
+	if err != nil {
+		return iri
+	}
+	parts.host = "[" + ip.String()
+	if hasZone {
+		parts.host += "%25" + zone
+	}
+	parts.host += "]"
+	normalized := iri
+	normalized.Authority = parts.String()
+	return normalized
+}
+
+// IsNormalized returns true if Normalize would return the same IRI.
+// It returns an error if the IRI cannot be normalized.
+func (iri IRI) IsNormalized() (bool, error) {
+	normalized, err := iri.Normalize()
+	if err != nil {
+		return false, err
+	}
+	return normalized == iri, nil
+}
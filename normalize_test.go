@@ -0,0 +1,345 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestNormalize(t *testing.T) {
+	tt := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "already normalized",
+			in:   "https://example.com/sub/path?q=1#frag1",
+			want: "https://example.com/sub/path?q=1#frag1",
+		},
+		{
+			name: "uppercase scheme and host",
+			in:   "HTTPS://User@EXAMPLE.com:8080/Path",
+			want: "https://User@example.com:8080/Path",
+		},
+		{
+			name: "lowercase percent-encoding",
+			in:   "https://example.com/dog%2fhouse?q=%c2%b5",
+			want: "https://example.com/dog%2Fhouse?q=µ",
+		},
+		{
+			name: "slash and question mark stay literal in fragment",
+			in:   "https://example.com/#a/b?c",
+			want: "https://example.com/#a/b?c",
+		},
+		{
+			name: "fragment dot segments are kept",
+			in:   "https://example.com/#a/./b/../c",
+			want: "https://example.com/#a/./b/../c",
+		},
+		{
+			name: "unreserved characters in fragment are decoded",
+			in:   "https://example.com/#%61",
+			want: "https://example.com/#a",
+		},
+		{
+			name: "reserved characters in fragment stay encoded",
+			in:   "https://example.com/#%2f%3F%23%25%20",
+			want: "https://example.com/#%2F%3F%23%25%20",
+		},
+		{
+			name: "dot segments",
+			in:   "https://example.com/a/./b/../c",
+			want: "https://example.com/a/c",
+		},
+		{
+			name: "relative reference keeps dot segments",
+			in:   "../a/./b",
+			want: "../a/./b",
+		},
+		{
+			name: "bracketed host",
+			in:   "http://[2001:DB8::1]:80/",
+			want: "http://[2001:db8::1]:80/",
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			in, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("IRI %q is not a valid IRI: %v", tc.in, err)
+			}
+			got, err := in.Normalize()
+			if err != nil {
+				t.Fatalf("Normalize() returned error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+			if got.String() != in.CanonicalString() {
+				t.Errorf("CanonicalString() = %q, want %q", in.CanonicalString(), got)
+			}
+		})
+	}
+}
+
+func TestCanonicalStringWithInvalidPercentEncoding(t *testing.T) {
+	t.Parallel()
+	value := iri.IRI{Scheme: "HTTP", Authority: "Example.com", Path: "/%ff/./a"}
+	if got, want := value.CanonicalString(), "http://example.com/%FF/a"; got != want {
+		t.Errorf("CanonicalString() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeHost(t *testing.T) {
+	tt := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "decoded before case-folded",
+			in:   "HTTP://%C3%89XAMPLE.com",
+			want: "http://éxample.com",
+		},
+		{
+			name: "percent-encoded ASCII letters are decoded and case-folded",
+			in:   "http://%41%42.EXAMPLE",
+			want: "http://ab.example",
+		},
+		{
+			name: "percent-encoded lowercase letters with lowercase digits",
+			in:   "http://%61%62.example/%41",
+			want: "http://ab.example/%41",
+		},
+		{
+			name: "percent-encoded letters next to a reserved octet",
+			in:   "http://%41%2f%42.example",
+			want: "http://a%2Fb.example",
+		},
+		{
+			name: "reserved octets stay encoded with uppercase digits",
+			in:   "http://A%2fB.example",
+			want: "http://a%2Fb.example",
+		},
+		{
+			name: "userinfo and port are untouched",
+			in:   "http://User%c2%b5@EXAMPLE.com:80/Path%c2%b5",
+			want: "http://User%c2%b5@example.com:80/Path%c2%b5",
+		},
+		{
+			name: "no authority",
+			in:   "MAILTO:User@EXAMPLE.com",
+			want: "mailto:User@EXAMPLE.com",
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			in, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("IRI %q is not a valid IRI: %v", tc.in, err)
+			}
+			if got := in.NormalizeHost(); got.String() != tc.want {
+				t.Errorf("NormalizeHost(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsNormalized(t *testing.T) {
+	tt := []struct {
+		in      iri.IRI
+		want    bool
+		wantErr bool
+	}{
+		{in: iri.IRI{}, want: true},
+		{in: iri.IRI{Scheme: "https", Authority: "example.com", Path: "/µ", Query: "q=%20"}, want: true},
+		{in: iri.IRI{Scheme: "HTTPS", Authority: "example.com"}, want: false},
+		{in: iri.IRI{Scheme: "https", Authority: "Example.com"}, want: false},
+		{in: iri.IRI{Scheme: "https", Authority: "example.com", Path: "/%C2%B5"}, want: false},
+		{in: iri.IRI{Scheme: "https", Authority: "example.com", Query: "q=%2f"}, want: false},
+		{in: iri.IRI{Scheme: "https", Authority: "example.com", Path: "/a/./b"}, want: false},
+		{in: iri.IRI{Path: "%FF"}, wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in.String(), func(t *testing.T) {
+			t.Parallel()
+			got, err := tc.in.IsNormalized()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("IsNormalized() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUppercasePercentHex(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "HTTP://User%2fa@EXAMPLE.com/%2f%c2%b5?q=%7e#%2a", want: "HTTP://User%2Fa@EXAMPLE.com/%2F%C2%B5?q=%7E#%2A"},
+		{in: "https://example.com/%C2%B5/%2F?q=%20", want: "https://example.com/%C2%B5/%2F?q=%20"},
+		{in: "https://example.com/abcdef?q=ab#cd", want: "https://example.com/abcdef?q=ab#cd"},
+		{in: "mailto:a%40b", want: "mailto:a%40b"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := iri.UppercasePercentHex(value); got.String() != tc.want {
+				t.Errorf("UppercasePercentHex(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeKeepsIPv6ZoneIdentifier(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "http://[fe80::1%25eth0]/", want: "http://[fe80::1%25eth0]/"},
+		{in: "http://[FE80::1%25Eth0]/", want: "http://[fe80::1%25Eth0]/"},
+		{in: "http://[fe80::1%25%65th0]/", want: "http://[fe80::1%25eth0]/"},
+		{in: "//[fe80::1%25eth0]", want: "//[fe80::1%25eth0]"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			in, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, err := in.Normalize()
+			if err != nil {
+				t.Fatalf("Normalize() returned error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+			if got.HostType() != iri.HostTypeIPv6 {
+				t.Errorf("HostType() = %v, want %v", got.HostType(), iri.HostTypeIPv6)
+			}
+		})
+	}
+}
+
+func TestParseRejectsUnencodedIPv6ZoneDelimiter(t *testing.T) {
+	t.Parallel()
+	if _, err := iri.Parse("http://[fe80::1%eth0]/"); err == nil {
+		t.Errorf("Parse() returned no error for unencoded zone delimiter")
+	}
+}
+
+func TestNormalizeIPv6(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "http://[2001:0DB8::0001]/", want: "http://[2001:db8::1]/"},
+		{in: "http://user@[2001:db8:0:0:0:0:2:1]:8080/a", want: "http://user@[2001:db8::2:1]:8080/a"},
+		{in: "http://[2001:db8:0:0:1:0:0:1]", want: "http://[2001:db8::1:0:0:1]"},
+		{in: "http://[2001:DB8::1%25Eth0]", want: "http://[2001:db8::1%25Eth0]"},
+		{in: "http://[::FFFF:192.0.2.1]", want: "http://[::ffff:192.0.2.1]"},
+		{in: "http://[v1.FE80::A]", want: "http://[v1.FE80::A]"},
+		{in: "http://192.0.2.1/", want: "http://192.0.2.1/"},
+		{in: "http://EXAMPLE.com/", want: "http://EXAMPLE.com/"},
+		{in: "urn:a:b", want: "urn:a:b"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			in, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := in.NormalizeIPv6(); got.String() != tc.want {
+				t.Errorf("NormalizeIPv6(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeSubDelims(t *testing.T) {
+	tt := []struct {
+		in         string
+		wantDecode string
+		wantKeep   string
+	}{
+		{
+			in:         "https://example.com/a%21b?x=%21%26y%3D%2b%3b%2C#%24%28%29",
+			wantDecode: "https://example.com/a!b?x=!%26y%3D%2B%3B,#$()",
+			wantKeep:   "https://example.com/a%21b?x=%21%26y%3D%2B%3B%2C#%24%28%29",
+		},
+		{
+			in:         "https://example.com/?q=a%26b",
+			wantDecode: "https://example.com/?q=a%26b",
+			wantKeep:   "https://example.com/?q=a%26b",
+		},
+		{
+			in:         "https://us%27er@ex%2aample.com/%26/%3D?%7e",
+			wantDecode: "https://us'er@ex*ample.com/&/=?~",
+			wantKeep:   "https://us%27er@ex%2Aample.com/%26/%3D?~",
+		},
+		{
+			in:         "https://example.com/%2F%3F%23%40%3A",
+			wantDecode: "https://example.com/%2F%3F%23%40%3A",
+			wantKeep:   "https://example.com/%2F%3F%23%40%3A",
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			in, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			decoded, err := iri.NormalizeSubDelims(in, true)
+			if err != nil {
+				t.Fatalf("NormalizeSubDelims() returned error: %v", err)
+			}
+			if decoded.String() != tc.wantDecode {
+				t.Errorf("NormalizeSubDelims(%q, true) = %q, want %q", tc.in, decoded, tc.wantDecode)
+			}
+			if _, err := iri.Parse(decoded.String()); err != nil {
+				t.Errorf("Parse() of decoded IRI returned error: %v", err)
+			}
+			kept, err := iri.NormalizeSubDelims(in, false)
+			if err != nil {
+				t.Fatalf("NormalizeSubDelims() returned error: %v", err)
+			}
+			if kept.String() != tc.wantKeep {
+				t.Errorf("NormalizeSubDelims(%q, false) = %q, want %q", tc.in, kept, tc.wantKeep)
+			}
+		})
+	}
+}
+
+func TestNormalizeSubDelimsWithInvalidPercentEncoding(t *testing.T) {
+	t.Parallel()
+	if _, err := iri.NormalizeSubDelims(iri.IRI{Path: "%FF%21"}, true); err == nil {
+		t.Errorf("NormalizeSubDelims() returned no error")
+	}
+}
@@ -0,0 +1,157 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseOption modifies the behaviour of Parse.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	maxPathSegments    int
+	maxAuthorityLength int
+	maxQueryLength     int
+	lowercaseScheme    bool
+	skipPercentCheck   bool
+	rejectPassword     bool
+	requirePath        bool
+	insertSlashPath    bool
+	escapeStrayPercent bool
+	rejectEmpty        bool
+}
+
+// MaxPathSegments limits the number of path segments, as counted before any dot-segment removal.
+// A value of zero or less means no limit, which is the default.
+func MaxPathSegments(n int) ParseOption {
+	return func(opts *parseOptions) { opts.maxPathSegments = n }
+}
+
+// MaxAuthorityLength limits the length of the authority, in bytes.
+// A value of zero or less means no limit, which is the default.
+func MaxAuthorityLength(n int) ParseOption {
+	return func(opts *parseOptions) { opts.maxAuthorityLength = n }
+}
+
+// MaxQueryLength limits the length of the query, in bytes.
+// A value of zero or less means no limit, which is the default.
+func MaxQueryLength(n int) ParseOption {
+	return func(opts *parseOptions) { opts.maxQueryLength = n }
+}
+
+// LowercaseScheme makes Parse return the scheme in lowercase.
+//
+// With this option, the string of the parsed IRI may differ from the input,
+// which breaks byte-exact round-tripping. This is expected.
+func LowercaseScheme() ParseOption {
+	return func(opts *parseOptions) { opts.lowercaseScheme = true }
+}
+
+// SkipPercentValidation makes Parse skip the validation of percent-encoded octets.
+//
+// The structure and the characters of all components are still checked, including that every
+// percent sign is followed by two hexadecimal digits. Yet, the percent-encoded octets are not
+// decoded, so the parsed IRI may contain percent-encoded octets that are not valid UTF-8.
+// Use this option only for trusted or otherwise validated input, for which parsing is performance-critical.
+func SkipPercentValidation() ParseOption {
+	return func(opts *parseOptions) { opts.skipPercentCheck = true }
+}
+
+// RejectDeprecatedUserinfoPassword makes Parse return an error if the userinfo of the authority
+// contains a colon (':'), which separates a password from the user name.
+//
+// RFC 3986 Section 3.2.1 deprecates the "user:password" format, as passing credentials in clear
+// text is a security risk. A percent-encoded colon ("%3A") is not considered a separator.
+func RejectDeprecatedUserinfoPassword() ParseOption {
+	return func(opts *parseOptions) { opts.rejectPassword = true }
+}
+
+// RequireNonEmptyPathForAuthority makes Parse return an error if the IRI has an authority, yet an empty path,
+// such as "http://host". If combined with NormalizeEmptyPathOnParse, the path is inserted instead where that option applies.
+func RequireNonEmptyPathForAuthority() ParseOption {
+	return func(opts *parseOptions) { opts.requirePath = true }
+}
+
+// NormalizeEmptyPathOnParse makes Parse insert the path "/" if the IRI has an authority, yet an empty path,
+// and if the profile registered for its scheme has EmptyPathToSlash set.
+// For example, "http://host?q" is parsed as "http://host/?q", while "ldap://host?q" is kept.
+//
+// This is the scheme-based normalization of schemes such as "http", see RFC 3986 Section 6.2.3,
+// and RegisterProfile for the schemes it applies to.
+// With this option, the string of the parsed IRI may differ from the input.
+func NormalizeEmptyPathOnParse() ParseOption {
+	return func(opts *parseOptions) { opts.insertSlashPath = true }
+}
+
+// TolerateStrayPercent makes Parse percent-encode every percent sign that is not followed by two
+// hexadecimal digits as "%25", so that input such as "?discount=50%" becomes valid.
+//
+// This is a convenience for real-world input that does not conform to RFC 3987.
+// With this option, the string of the parsed IRI may differ from the input.
+func TolerateStrayPercent() ParseOption {
+	return func(opts *parseOptions) { opts.escapeStrayPercent = true }
+}
+
+// RejectEmpty makes Parse return an error for the empty string.
+//
+// Without this option, the empty string is a valid relative reference, which is parsed as the zero IRI.
+// This option allows callers to treat empty input as a mistake, such as a missing configuration value.
+func RejectEmpty() ParseOption {
+	return func(opts *parseOptions) { opts.rejectEmpty = true }
+}
+
+func newParseOptions(opts []ParseOption) parseOptions {
+	var result parseOptions
+	for _, opt := range opts {
+		opt(&result)
+	}
+	return result
+}
+
+func (opts parseOptions) checkLimits(authority, path, query string) error {
+	if (opts.maxAuthorityLength > 0) && (len(authority) > opts.maxAuthorityLength) {
+		return fmt.Errorf("authority length %d exceeds limit of %d", len(authority), opts.maxAuthorityLength)
+	}
+	if segments := pathSegmentCount(path); (opts.maxPathSegments > 0) && (segments > opts.maxPathSegments) {
+		return fmt.Errorf("path segment count %d exceeds limit of %d", segments, opts.maxPathSegments)
+	}
+	if (opts.maxQueryLength > 0) && (len(query) > opts.maxQueryLength) {
+		return fmt.Errorf("query length %d exceeds limit of %d", len(query), opts.maxQueryLength)
+	}
+	return nil
+}
+
+func (opts parseOptions) checkAuthority(authority string) error {
+	if parts := splitAuthority(authority); opts.rejectPassword && strings.Contains(parts.userInfo, ":") {
+		return fmt.Errorf("userinfo contains a deprecated password")
+	}
+	return nil
+}
+
+func (opts parseOptions) adjustEmptyPath(iri IRI) (IRI, error) {
+	if !iri.hasAuthority() || (iri.Path != "") {
+		return iri, nil
+	}
+	if profile, _ := LookupProfile(iri.Scheme); opts.insertSlashPath && profile.EmptyPathToSlash {
+		adjusted := iri
+		adjusted.Path = "/"
+		return adjusted, nil
+	}
+	if opts.requirePath {
+		return IRI{}, fmt.Errorf("path is empty, yet there is an authority")
+	}
+	return iri, nil
+}
+
+// pathSegmentCount returns the number of segments of the path.
+// A leading slash does not start an additional segment; The path "/" has one empty segment.
+func pathSegmentCount(path string) int {
+	if path == "" {
+		return 0
+	}
+	count := strings.Count(path, "/")
+	if !strings.HasPrefix(path, "/") {
+		count++
+	}
+	return count
+}
@@ -0,0 +1,262 @@
+package iri_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestParseLimits(t *testing.T) {
+	tt := []struct {
+		name    string
+		in      string
+		opts    []iri.ParseOption
+		wantErr bool
+	}{
+		{name: "no limits", in: "https://user@example.com/a/b/c/../../..?q=1234"},
+		{name: "path segments within limit", in: "https://example.com/a/b/c", opts: []iri.ParseOption{iri.MaxPathSegments(3)}},
+		{name: "path segments exceeded", in: "https://example.com/a/b/c/d", opts: []iri.ParseOption{iri.MaxPathSegments(3)}, wantErr: true},
+		{name: "path segments counted before dot-segment removal", in: "/a/../b/..", opts: []iri.ParseOption{iri.MaxPathSegments(3)}, wantErr: true},
+		{name: "relative path segments", in: "a/b/c", opts: []iri.ParseOption{iri.MaxPathSegments(2)}, wantErr: true},
+		{name: "trailing empty segment", in: "/a/", opts: []iri.ParseOption{iri.MaxPathSegments(1)}, wantErr: true},
+		{name: "authority within limit", in: "https://example.com", opts: []iri.ParseOption{iri.MaxAuthorityLength(11)}},
+		{name: "authority exceeded", in: "https://user@example.com", opts: []iri.ParseOption{iri.MaxAuthorityLength(11)}, wantErr: true},
+		{name: "query within limit", in: "?q=12", opts: []iri.ParseOption{iri.MaxQueryLength(4)}},
+		{name: "query exceeded", in: "?q=123", opts: []iri.ParseOption{iri.MaxQueryLength(4)}, wantErr: true},
+		{name: "zero is unlimited", in: "https://user@example.com/a/b?q=1", opts: []iri.ParseOption{iri.MaxPathSegments(0), iri.MaxAuthorityLength(0), iri.MaxQueryLength(0)}},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in, tc.opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && (got.String() != tc.in) {
+				t.Errorf("Parse(%q) = %q", tc.in, got)
+			}
+		})
+	}
+}
+
+func TestParseLowercaseScheme(t *testing.T) {
+	tt := []struct {
+		name       string
+		in         string
+		opts       []iri.ParseOption
+		wantScheme string
+		want       string
+	}{
+		{name: "off by default", in: "HTTPS://X/Path", wantScheme: "HTTPS", want: "HTTPS://X/Path"},
+		{name: "on", in: "HTTPS://X/Path", opts: []iri.ParseOption{iri.LowercaseScheme()}, wantScheme: "https", want: "https://X/Path"},
+		{name: "mixed case", in: "Urn:X", opts: []iri.ParseOption{iri.LowercaseScheme()}, wantScheme: "urn", want: "urn:X"},
+		{name: "no scheme", in: "//X/Path", opts: []iri.ParseOption{iri.LowercaseScheme()}, wantScheme: "", want: "//X/Path"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in, tc.opts...)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got.Scheme != tc.wantScheme {
+				t.Errorf("Scheme = %q, want %q", got.Scheme, tc.wantScheme)
+			}
+			if got.String() != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseSkipPercentValidation(t *testing.T) {
+	tt := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{
+		{name: "valid percent-encoding", in: "https://example.com/%C2%B5?q=%20"},
+		{name: "invalid UTF-8 is accepted", in: "https://example.com/%FF?q=%C0%AF"},
+		{name: "percent sign without hexadecimal digits is rejected", in: "https://example.com/%zz", wantErr: true},
+		{name: "truncated percent-encoding is rejected", in: "https://example.com/%4", wantErr: true},
+		{name: "invalid characters are rejected", in: "https://example.com/a b", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in, iri.SkipPercentValidation())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && (got.String() != tc.in) {
+				t.Errorf("Parse(%q) = %q", tc.in, got)
+			}
+		})
+	}
+}
+
+func BenchmarkParsePercentEncoded(b *testing.B) {
+	in := "https://example.com/" + strings.Repeat("%C2%B5%E2%82%AC/", 50) + "?q=" + strings.Repeat("%20%2F", 50)
+	benchmarks := []struct {
+		name string
+		opts []iri.ParseOption
+	}{
+		{name: "default"},
+		{name: "SkipPercentValidation", opts: []iri.ParseOption{iri.SkipPercentValidation()}},
+	}
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := iri.Parse(in, bm.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRejectDeprecatedUserinfoPassword(t *testing.T) {
+	tt := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: "//user@host"},
+		{in: "//host:80"},
+		{in: "//user@host:80"},
+		{in: "//user%3Apw@host"},
+		{in: "https://[::1]:443/a:b?c:d#e:f"},
+		{in: "//user:pw@host", wantErr: true},
+		{in: "https://user:@host", wantErr: true},
+		{in: "https://:pw@[::1]:443", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in, iri.RejectDeprecatedUserinfoPassword())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && (got.String() != tc.in) {
+				t.Errorf("Parse(%q) = %q", tc.in, got)
+			}
+			if _, err := iri.Parse(tc.in); err != nil {
+				t.Errorf("Parse() without option returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseEmptyPathOptions(t *testing.T) {
+	tt := []struct {
+		name    string
+		in      string
+		opts    []iri.ParseOption
+		want    string
+		wantErr bool
+	}{
+		{name: "default keeps empty path", in: "http://host", want: "http://host"},
+		{name: "require rejects empty path", in: "http://host", opts: []iri.ParseOption{iri.RequireNonEmptyPathForAuthority()}, wantErr: true},
+		{name: "require rejects empty path with query", in: "http://host?q", opts: []iri.ParseOption{iri.RequireNonEmptyPathForAuthority()}, wantErr: true},
+		{name: "require rejects empty authority and path", in: "file://", opts: []iri.ParseOption{iri.RequireNonEmptyPathForAuthority()}, wantErr: true},
+		{name: "require accepts slash", in: "http://host/", opts: []iri.ParseOption{iri.RequireNonEmptyPathForAuthority()}, want: "http://host/"},
+		{name: "require ignores IRIs without authority", in: "mailto:", opts: []iri.ParseOption{iri.RequireNonEmptyPathForAuthority()}, want: "mailto:"},
+		{name: "normalize inserts slash", in: "http://host", opts: []iri.ParseOption{iri.NormalizeEmptyPathOnParse()}, want: "http://host/"},
+		{name: "normalize inserts slash before query", in: "http://host?q#f", opts: []iri.ParseOption{iri.NormalizeEmptyPathOnParse()}, want: "http://host/?q#f"},
+		{name: "normalize keeps path", in: "http://host/a", opts: []iri.ParseOption{iri.NormalizeEmptyPathOnParse()}, want: "http://host/a"},
+		{name: "normalize ignores IRIs without authority", in: "?q", opts: []iri.ParseOption{iri.NormalizeEmptyPathOnParse()}, want: "?q"},
+		{name: "normalize keeps other schemes", in: "ldap://h?x", opts: []iri.ParseOption{iri.NormalizeEmptyPathOnParse()}, want: "ldap://h?x"},
+		{name: "normalize keeps references without scheme", in: "//host?q", opts: []iri.ParseOption{iri.NormalizeEmptyPathOnParse()}, want: "//host?q"},
+		{name: "normalize inserts slash for file", in: "file://host", opts: []iri.ParseOption{iri.NormalizeEmptyPathOnParse()}, want: "file://host/"},
+		{name: "normalize compares scheme case-insensitively", in: "WSS://host", opts: []iri.ParseOption{iri.NormalizeEmptyPathOnParse()}, want: "WSS://host/"},
+		{name: "require applies to other schemes", in: "ldap://h", opts: []iri.ParseOption{iri.RequireNonEmptyPathForAuthority(), iri.NormalizeEmptyPathOnParse()}, wantErr: true},
+		{name: "normalize takes precedence", in: "http://host", opts: []iri.ParseOption{iri.RequireNonEmptyPathForAuthority(), iri.NormalizeEmptyPathOnParse()}, want: "http://host/"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in, tc.opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("Parse(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseTolerateStrayPercent(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "https://shop.example/?discount=50%", want: "https://shop.example/?discount=50%25"},
+		{in: "https://shop.example/?discount=50%25", want: "https://shop.example/?discount=50%25"},
+		{in: "https://shop.example/100%/x%4", want: "https://shop.example/100%25/x%254"},
+		{in: "https://shop.example/%zz#%", want: "https://shop.example/%25zz#%25"},
+		{in: "https://shop.example/%C2%B5", want: "https://shop.example/%C2%B5"},
+		{in: "https://shop.example/%FF", wantErr: true},
+		{in: "https://shop example/", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			if _, err := iri.Parse(tc.in); (err == nil) && (tc.in != tc.want) {
+				t.Errorf("Parse(%q) without option returned no error", tc.in)
+			}
+			got, err := iri.Parse(tc.in, iri.TolerateStrayPercent())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("Parse(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectEmpty(t *testing.T) {
+	tt := []struct {
+		in      string
+		opts    []iri.ParseOption
+		wantErr bool
+	}{
+		{in: ""},
+		{in: "", opts: []iri.ParseOption{iri.RejectEmpty()}, wantErr: true},
+		{in: "a", opts: []iri.ParseOption{iri.RejectEmpty()}},
+		{in: "#", opts: []iri.ParseOption{iri.RejectEmpty()}},
+		{in: "?", opts: []iri.ParseOption{iri.RejectEmpty()}},
+		{in: "//", opts: []iri.ParseOption{iri.RejectEmpty()}},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(fmt.Sprintf("%q with %d options", tc.in, len(tc.opts)), func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.Parse(tc.in, tc.opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if (err == nil) && (got.String() != tc.in) {
+				t.Errorf("Parse(%q) = %q", tc.in, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package iri
+
+import "strings"
+
+// Base returns the last segment of the path, percent-decoded.
+//
+// Trailing slashes are removed before the last segment is determined, similar to "path.Base".
+// If the path is empty or consists only of slashes, the result is empty.
+// An encoded slash ("%2F") is part of the segment and is decoded as such.
+// If the segment contains invalid percent-encoding, it is returned as is.
+func (iri IRI) Base() string {
+	trimmed := strings.TrimRight(iri.Path, "/")
+	segment := trimmed[strings.LastIndexByte(trimmed, '/')+1:]
+	decoded, err := Unescape(segment)
+	if err != nil {
+		return segment
+	}
+	return decoded
+}
+
+// Ext returns the file name extension of Base, including the dot.
+// The result is empty if Base contains no dot.
+func (iri IRI) Ext() string {
+	base := iri.Base()
+	if i := strings.LastIndexByte(base, '.'); i >= 0 {
+		return base[i:]
+	}
+	return ""
+}
+
+// CollapseSlashes returns an IRI in which every run of consecutive slashes in the path is
+// reduced to a single slash. All other components, including the "//" before the authority, are kept.
+//
+// This is not a normalization defined by the RFCs, as empty path segments are significant.
+// It is meant for applications that know that their servers treat such paths as equivalent.
+func (iri IRI) CollapseSlashes() IRI {
+	collapsed := iri
+	var result strings.Builder
+	previousSlash := false
+	for i := 0; i < len(iri.Path); i++ {
+		isSlash := iri.Path[i] == '/'
+		if !isSlash || !previousSlash {
+			result.WriteByte(iri.Path[i])
+		}
+		previousSlash = isSlash
+	}
+	collapsed.Path = result.String()
+	return collapsed
+}
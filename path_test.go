@@ -0,0 +1,73 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestBaseAndExt(t *testing.T) {
+	tt := []struct {
+		in       string
+		wantBase string
+		wantExt  string
+	}{
+		{in: "https://a/b/c.txt?x", wantBase: "c.txt", wantExt: ".txt"},
+		{in: "https://a/b/c.tar.gz#frag", wantBase: "c.tar.gz", wantExt: ".gz"},
+		{in: "https://a/b/c/", wantBase: "c"},
+		{in: "https://a/b/c.d//", wantBase: "c.d", wantExt: ".d"},
+		{in: "https://a/", wantBase: ""},
+		{in: "https://a", wantBase: ""},
+		{in: "https://a?q=b.txt", wantBase: ""},
+		{in: "https://a/b%2Fc.txt", wantBase: "b/c.txt", wantExt: ".txt"},
+		{in: "https://a/%C2%B5%20file.txt", wantBase: "µ file.txt", wantExt: ".txt"},
+		{in: "mailto:user@example.com", wantBase: "user@example.com", wantExt: ".com"},
+		{in: "file", wantBase: "file"},
+		{in: "", wantBase: ""},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.Base(); got != tc.wantBase {
+				t.Errorf("Base() = %q, want %q", got, tc.wantBase)
+			}
+			if got := value.Ext(); got != tc.wantExt {
+				t.Errorf("Ext() = %q, want %q", got, tc.wantExt)
+			}
+		})
+	}
+}
+
+func TestCollapseSlashes(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "/a//b///c", want: "/a/b/c"},
+		{in: "http://host//a//b/?q=//#//", want: "http://host/a/b/?q=//#//"},
+		{in: "http://host", want: "http://host"},
+		{in: "file:///", want: "file:///"},
+		{in: "a//b", want: "a/b"},
+		{in: "https://example.com/a/b", want: "https://example.com/a/b"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.CollapseSlashes(); got.String() != tc.want {
+				t.Errorf("CollapseSlashes() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
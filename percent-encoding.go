@@ -0,0 +1,102 @@
+package iri
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// PercentEncodingError describes an invalid percent-encoded sequence in a component of an IRI.
+type PercentEncodingError struct {
+	// Component is the component that contains the sequence.
+	Component Component
+	// Offset is the byte offset of the sequence within the component.
+	Offset int
+	// Sequence is the invalid sequence, as found in the component.
+	Sequence string
+	// Reason describes why the sequence is invalid.
+	Reason string
+}
+
+// Error returns a description of the error.
+func (err *PercentEncodingError) Error() string {
+	return fmt.Sprintf("%s contains invalid percent-encoding %q at offset %d: %s", err.Component, err.Sequence, err.Offset, err.Reason)
+}
+
+// ValidatePercentEncoding checks the percent-encoding of all components of the IRI,
+// and returns an error for every invalid sequence. The errors are of type *PercentEncodingError.
+//
+// A sequence is invalid if a percent sign is not followed by two hexadecimal digits,
+// or if the percent-encoded octets are not valid UTF-8. Unlike NormalizePercentEncoding,
+// this function does not stop at the first error.
+// It returns nil if the percent-encoding of all components is valid.
+func ValidatePercentEncoding(iri IRI) []error {
+	var errs []error
+	errs = append(errs, validatePercentEncoding(ComponentAuthority, iri.Authority)...)
+	errs = append(errs, validatePercentEncoding(ComponentPath, iri.Path)...)
+	errs = append(errs, validatePercentEncoding(ComponentQuery, iri.Query)...)
+	errs = append(errs, validatePercentEncoding(ComponentFragment, iri.Fragment)...)
+	return errs
+}
+
+func validatePercentEncoding(component Component, s string) []error {
+	var errs []error
+	for i := 0; i < len(s); {
+		if s[i] != '%' {
+			i++
+			continue
+		}
+		var octets []byte
+		var offsets []int
+		for (i < len(s)) && (s[i] == '%') {
+			if (i+2 >= len(s)) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+				errs = append(errs, &PercentEncodingError{Component: component, Offset: i, Sequence: s[i:minInt(i+3, len(s))], Reason: "not followed by two hexadecimal digits"})
+				i++
+				break
+			}
+			octets = append(octets, octetsFrom(s[i:i+3])...)
+			offsets = append(offsets, i)
+			i += 3
+		}
+		for j := 0; j < len(octets); {
+			r, size := utf8.DecodeRune(octets[j:])
+			if (r == utf8.RuneError) && (size <= 1) {
+				errs = append(errs, &PercentEncodingError{Component: component, Offset: offsets[j], Sequence: s[offsets[j] : offsets[j]+3], Reason: "invalid UTF-8"})
+				size = 1
+			}
+			j += size
+		}
+	}
+	return errs
+}
+
+// HasDoubleEncoding returns true if any component of the IRI contains a percent-encoded percent sign
+// that is followed by two hexadecimal digits, such as "%2520". Decoding such a sequence results in
+// another percent-encoded octet, here "%20", which indicates that the text was encoded twice.
+//
+// This is a heuristic: It flags potential double-encoding, as the text may as well contain a literal
+// percent sign followed by two hexadecimal digits on purpose, such as in "%2550" for "%50".
+// The scheme is not considered, as it cannot contain percent-encoding.
+func (iri IRI) HasDoubleEncoding() bool {
+	for _, s := range []string{iri.Authority, iri.Path, iri.Query, iri.Fragment} {
+		if hasDoubleEncoding(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDoubleEncoding(s string) bool {
+	for i := 0; i+4 < len(s); i++ {
+		if isPercentEncoded(s, i) && (s[i+1] == '2') && (s[i+2] == '5') && isHex(s[i+3]) && isHex(s[i+4]) {
+			return true
+		}
+	}
+	return false
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,95 @@
+package iri_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestValidatePercentEncoding(t *testing.T) {
+	tt := []struct {
+		name string
+		in   iri.IRI
+		want []iri.PercentEncodingError
+	}{
+		{
+			name: "valid",
+			in:   iri.IRI{Scheme: "https", Authority: "%C2%B5", Path: "/%20", Query: "%c2%b5", Fragment: "%2F"},
+			want: nil,
+		},
+		{
+			name: "path and query",
+			in:   iri.IRI{Path: "/a/%FF", Query: "q=%C2&r=%zz"},
+			want: []iri.PercentEncodingError{
+				{Component: iri.ComponentPath, Offset: 3, Sequence: "%FF", Reason: "invalid UTF-8"},
+				{Component: iri.ComponentQuery, Offset: 2, Sequence: "%C2", Reason: "invalid UTF-8"},
+				{Component: iri.ComponentQuery, Offset: 8, Sequence: "%zz", Reason: "not followed by two hexadecimal digits"},
+			},
+		},
+		{
+			name: "all components",
+			in:   iri.IRI{Authority: "%B5", Path: "%", Query: "%2", Fragment: "%C2%B5%B5"},
+			want: []iri.PercentEncodingError{
+				{Component: iri.ComponentAuthority, Offset: 0, Sequence: "%B5", Reason: "invalid UTF-8"},
+				{Component: iri.ComponentPath, Offset: 0, Sequence: "%", Reason: "not followed by two hexadecimal digits"},
+				{Component: iri.ComponentQuery, Offset: 0, Sequence: "%2", Reason: "not followed by two hexadecimal digits"},
+				{Component: iri.ComponentFragment, Offset: 6, Sequence: "%B5", Reason: "invalid UTF-8"},
+			},
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []iri.PercentEncodingError
+			for _, err := range iri.ValidatePercentEncoding(tc.in) {
+				var encodingErr *iri.PercentEncodingError
+				if !errors.As(err, &encodingErr) {
+					t.Fatalf("error is of unexpected type %T", err)
+				}
+				got = append(got, *encodingErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ValidatePercentEncoding() =\n  %+v, want\n  %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHasDoubleEncoding(t *testing.T) {
+	tt := []struct {
+		in   string
+		want bool
+	}{
+		{in: "https://example.com/a%2520b", want: true},
+		{in: "https://example.com/a%20b", want: false},
+		{in: "https://example.com/a%25", want: false},
+		{in: "https://example.com/a%252", want: false},
+		{in: "https://example.com/50%25off", want: false},
+		{in: "https://example.com/%252F", want: true},
+		{in: "https://example.com/%252f", want: true},
+		{in: "https://example.com/?q=%25C2%25B5", want: true},
+		{in: "https://example.com/#%2541", want: true},
+		{in: "https://us%2541@example.com/", want: true},
+		{in: "https://example.com/%2525", want: true},
+		{in: "https://example.com/2520", want: false},
+		{in: "", want: false},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.HasDoubleEncoding(); got != tc.want {
+				t.Errorf("HasDoubleEncoding(%q) = %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package iri
+
+import (
+	"strings"
+	"sync"
+)
+
+// A Profile describes the scheme-based normalization of IRIs of a particular scheme.
+//
+// See https://www.rfc-editor.org/rfc/rfc3986#section-6.2.3.
+type Profile struct {
+	// DefaultPort is removed from the authority, as is an empty port. If empty, no port is removed.
+	DefaultPort string
+	// LowercaseHost makes the host case-insensitive.
+	LowercaseHost bool
+	// EmptyPathToSlash replaces an empty path with "/" if the IRI has an authority.
+	EmptyPathToSlash bool
+}
+
+// defaultProfile applies to all schemes without a registered profile.
+// The host is case-insensitive for all schemes, as per RFC 3986 Section 6.2.2.1.
+var defaultProfile = Profile{LowercaseHost: true}
+
+var (
+	profilesMutex sync.RWMutex
+	profiles      = builtinProfiles()
+)
+
+// builtinProfiles returns the profiles of the special schemes, with the default ports of IRI.DefaultPort.
+func builtinProfiles() map[string]Profile {
+	builtin := make(map[string]Profile, len(specialSchemes))
+	for scheme := range specialSchemes {
+		builtin[scheme] = Profile{DefaultPort: defaultPorts[scheme], LowercaseHost: true, EmptyPathToSlash: true}
+	}
+	return builtin
+}
+
+// RegisterProfile registers the profile for the given scheme, replacing any previous one.
+// The scheme is compared case-insensitively.
+// Built-in profiles are registered for the special schemes "file", "ftp", "http", "https", "ws", and "wss".
+//
+// This function is safe for concurrent use.
+func RegisterProfile(scheme string, profile Profile) {
+	profilesMutex.Lock()
+	defer profilesMutex.Unlock()
+	profiles[strings.ToLower(scheme)] = profile
+}
+
+// LookupProfile returns the profile registered for the given scheme, and false if there is none.
+// The scheme is compared case-insensitively.
+func LookupProfile(scheme string) (Profile, bool) {
+	profilesMutex.RLock()
+	defer profilesMutex.RUnlock()
+	profile, registered := profiles[strings.ToLower(scheme)]
+	return profile, registered
+}
+
+// EqualUnderProfile returns true if both IRIs are equal after syntax-based normalization
+// and the scheme-based normalization of the profile of their respective scheme.
+// For schemes without a registered profile, only the host is case-insensitive.
+//
+// Unlike EqualNormalized, this function returns an error if either IRI contains invalid percent-encoding.
+func EqualUnderProfile(a, b IRI) (bool, error) {
+	normalizedA, err := a.normalizeUnderProfile()
+	if err != nil {
+		return false, err
+	}
+	normalizedB, err := b.normalizeUnderProfile()
+	if err != nil {
+		return false, err
+	}
+	return normalizedA.String() == normalizedB.String(), nil
+}
+
+func (iri IRI) normalizeUnderProfile() (IRI, error) {
+	profile, registered := LookupProfile(iri.Scheme)
+	if !registered {
+		profile = defaultProfile
+	}
+	return profile.normalize(iri)
+}
+
+func (profile Profile) normalize(iri IRI) (IRI, error) {
+	normalized, err := NormalizePercentEncoding(iri)
+	if err != nil {
+		return IRI{}, err
+	}
+	normalized = normalized.normalizeSyntaxWith(profile.LowercaseHost).stripPort(profile.DefaultPort)
+	if profile.EmptyPathToSlash && normalized.hasAuthority() && (normalized.Path == "") {
+		normalized.Path = "/"
+	}
+	return normalized, nil
+}
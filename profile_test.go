@@ -0,0 +1,134 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestEqualUnderProfile(t *testing.T) {
+	tt := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "http://example.com:80/a", b: "http://example.com/a", want: true},
+		{a: "http://example.com:/a", b: "http://example.com/a", want: true},
+		{a: "https://example.com:443/a", b: "https://example.com/a", want: true},
+		{a: "https://example.com:80/a", b: "https://example.com/a", want: false},
+		{a: "http://example.com:8080/a", b: "http://example.com/a", want: false},
+		{a: "ftp://example.com:21/a", b: "ftp://example.com/a", want: true},
+		{a: "ws://h:80", b: "ws://h", want: true},
+		{a: "wss://h:443/a", b: "wss://h/a", want: true},
+		{a: "wss://h:80/a", b: "wss://h/a", want: false},
+		{a: "HTTP://Example.COM/a", b: "http://example.com/a", want: true},
+		{a: "http://example.com", b: "http://example.com/", want: true},
+		{a: "file://host", b: "file://host/", want: true},
+		{a: "http://example.com/a/./b/../c", b: "http://example.com/a/c", want: true},
+		{a: "http://example.com/%7e", b: "http://example.com/~", want: true},
+		{a: "http://example.com/A", b: "http://example.com/a", want: false},
+		{a: "mailto:User@example.com", b: "mailto:user@example.com", want: false},
+		{a: "mailto:user@example.com", b: "MAILTO:user@example.com", want: true},
+		{a: "other://example.com:80", b: "other://example.com", want: false},
+		{a: "other://EXAMPLE.com", b: "other://example.com", want: true},
+		{a: "other://example.com", b: "other://example.com/", want: false},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			t.Parallel()
+			a, errA := iri.Parse(tc.a)
+			b, errB := iri.Parse(tc.b)
+			if (errA != nil) || (errB != nil) {
+				t.Fatalf("Parse() returned errors: %v, %v", errA, errB)
+			}
+			got, err := iri.EqualUnderProfile(a, b)
+			if err != nil {
+				t.Fatalf("EqualUnderProfile() returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("EqualUnderProfile() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEqualUnderProfileMatchesComposedHelpers(t *testing.T) {
+	// The profile of "http" has all normalizations enabled.
+	tt := []string{
+		"HTTP://Example.COM:80",
+		"http://example.com/",
+		"http://example.com:/a/./b/../c",
+		"http://EXAMPLE.com:0080/a/c",
+		"http://example.com/%7e%2f",
+		"http://example.com/~%2F",
+		"http://example.com:8080/A?%3f#%3F",
+		"http://user@Example.com/a/b/..",
+		"http:/a/../b",
+		"http:a/../b",
+	}
+	t.Parallel()
+	values := make([]iri.IRI, len(tt))
+	composed := make([]string, len(tt))
+	for i, in := range tt {
+		value, err := iri.Parse(in)
+		if err != nil {
+			t.Fatalf("Parse() returned error: %v", err)
+		}
+		normalized, err := value.Normalize()
+		if err != nil {
+			t.Fatalf("Normalize() returned error: %v", err)
+		}
+		normalized = normalized.StripDefaultPort()
+		if (normalized.Authority != "") && (normalized.Path == "") {
+			normalized.Path = "/"
+		}
+		values[i], composed[i] = value, normalized.String()
+	}
+	for i := range values {
+		for j := range values {
+			got, err := iri.EqualUnderProfile(values[i], values[j])
+			if err != nil {
+				t.Fatalf("EqualUnderProfile() returned error: %v", err)
+			}
+			if want := composed[i] == composed[j]; got != want {
+				t.Errorf("EqualUnderProfile(%q, %q) = %v, want %v", values[i], values[j], got, want)
+			}
+		}
+	}
+}
+
+func TestEqualUnderProfileErrors(t *testing.T) {
+	t.Parallel()
+	valid := iri.IRI{Scheme: "http", Authority: "example.com", Path: "/"}
+	invalid := iri.IRI{Scheme: "http", Authority: "example.com", Path: "/%FF"}
+	if _, err := iri.EqualUnderProfile(invalid, valid); err == nil {
+		t.Errorf("EqualUnderProfile() did not return an error for the first IRI")
+	}
+	if _, err := iri.EqualUnderProfile(valid, invalid); err == nil {
+		t.Errorf("EqualUnderProfile() did not return an error for the second IRI")
+	}
+}
+
+func TestRegisterProfile(t *testing.T) {
+	t.Parallel()
+	const scheme = "x-test-profile"
+	if _, registered := iri.LookupProfile(scheme); registered {
+		t.Fatalf("profile already registered")
+	}
+	iri.RegisterProfile("X-Test-Profile", iri.Profile{DefaultPort: "1234"})
+	t.Cleanup(func() { iri.UnregisterProfile(scheme) })
+	profile, registered := iri.LookupProfile(scheme)
+	if !registered || (profile.DefaultPort != "1234") {
+		t.Fatalf("LookupProfile() = %#v, %v", profile, registered)
+	}
+	a := iri.IRI{Scheme: scheme, Authority: "Host:1234"}
+	b := iri.IRI{Scheme: scheme, Authority: "Host"}
+	c := iri.IRI{Scheme: scheme, Authority: "host"}
+	if equal, err := iri.EqualUnderProfile(a, b); err != nil || !equal {
+		t.Errorf("default port not stripped: %v, %v", equal, err)
+	}
+	if equal, err := iri.EqualUnderProfile(b, c); err != nil || equal {
+		t.Errorf("host compared case-insensitively: %v, %v", equal, err)
+	}
+}
@@ -0,0 +1,134 @@
+package iri
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AppendQueryParam returns an IRI with the key/value pair appended to the query.
+//
+// The key and value are escaped with QueryEscape, and separated from an existing
+// query with an ampersand ('&'). The existing query is kept as it is.
+func (iri IRI) AppendQueryParam(key, value string) IRI {
+	appended := iri
+	if iri.Query != "" {
+		appended.Query += "&"
+	}
+	appended.Query += QueryEscape(key) + "=" + QueryEscape(value)
+	return appended
+}
+
+// WithQueryParams returns an IRI with the query replaced by the given key/value pairs,
+// in the form "key1=value1&key2=value2". Keys and values are escaped with QueryEscape,
+// and their order is preserved.
+//
+// The pairs are given as alternating keys and values; It returns an error for an odd count.
+// Without pairs, the returned IRI has no query. Use WithForceQuery to keep the question mark ('?').
+func (iri IRI) WithQueryParams(pairs ...string) (IRI, error) {
+	if len(pairs)%2 != 0 {
+		return IRI{}, fmt.Errorf("odd count of %d query key/value pairs", len(pairs))
+	}
+	fields := make([]string, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		fields = append(fields, QueryEscape(pairs[i])+"="+QueryEscape(pairs[i+1]))
+	}
+	result := iri
+	result.Query = strings.Join(fields, "&")
+	result.ForceQuery = false
+	return result, nil
+}
+
+// WithForceQuery returns an IRI with the ForceQuery flag set, so that the question mark ('?')
+// is written even if the query is empty.
+func (iri IRI) WithForceQuery() IRI {
+	result := iri
+	result.ForceQuery = true
+	return result
+}
+
+// SortQuery returns an IRI with the key/value pairs of the query sorted by key, then by value.
+//
+// The sort is stable, so duplicate pairs keep their relative order. Keys and values are compared
+// after decoding with QueryUnescape, yet each pair keeps its original percent-encoding.
+// A pair that cannot be decoded is compared by its original text.
+//
+// This produces a canonical form for purposes such as cache keys. Note that it may change the
+// meaning of the IRI for servers that consider the order of query parameters.
+func (iri IRI) SortQuery() IRI {
+	if iri.Query == "" {
+		return iri
+	}
+	type field struct {
+		raw        string
+		key, value string
+	}
+	rawFields := strings.Split(iri.Query, "&")
+	fields := make([]field, len(rawFields))
+	for i, raw := range rawFields {
+		rawKey, rawValue, _ := strings.Cut(raw, "=")
+		fields[i] = field{raw: raw, key: queryUnescapeOrRaw(rawKey), value: queryUnescapeOrRaw(rawValue)}
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].key != fields[j].key {
+			return fields[i].key < fields[j].key
+		}
+		return fields[i].value < fields[j].value
+	})
+	for i, f := range fields {
+		rawFields[i] = f.raw
+	}
+	sorted := iri
+	sorted.Query = strings.Join(rawFields, "&")
+	return sorted
+}
+
+// RemoveQueryParams returns an IRI without the key/value pairs of the query that have any of the given keys.
+// Keys are compared case-sensitively, after decoding with QueryUnescape.
+//
+// This is typically used to strip tracking parameters, such as "utm_source". See RemoveQueryParamsMatching
+// for other ways to select the pairs. The remaining pairs keep their order and their original percent-encoding.
+func (iri IRI) RemoveQueryParams(names ...string) IRI {
+	return iri.RemoveQueryParamsMatching(func(key string) bool {
+		for _, name := range names {
+			if key == name {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// RemoveQueryParamsMatching returns an IRI without the key/value pairs of the query for which the given
+// function returns true. The function is called with the key of each pair, decoded with QueryUnescape.
+// A key that cannot be decoded is passed as it is.
+//
+// The remaining pairs keep their order and their original percent-encoding. If all pairs are removed,
+// the returned IRI has no query, including no question mark ('?').
+func (iri IRI) RemoveQueryParamsMatching(remove func(key string) bool) IRI {
+	if iri.Query == "" {
+		return iri
+	}
+	rawFields := strings.Split(iri.Query, "&")
+	kept := rawFields[:0]
+	for _, raw := range rawFields {
+		rawKey, _, _ := strings.Cut(raw, "=")
+		if !remove(queryUnescapeOrRaw(rawKey)) {
+			kept = append(kept, raw)
+		}
+	}
+	if len(kept) == len(rawFields) {
+		return iri
+	}
+	result := iri
+	result.Query = strings.Join(kept, "&")
+	result.ForceQuery = false
+	return result
+}
+
+func queryUnescapeOrRaw(s string) string {
+	if unescaped, err := QueryUnescape(s); err == nil {
+		return unescaped
+	}
+	return s
+}
@@ -0,0 +1,170 @@
+package iri_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestAppendQueryParam(t *testing.T) {
+	tt := []struct {
+		name       string
+		in         string
+		key, value string
+		want       string
+	}{
+		{name: "empty query", in: "https://example.com/path", key: "a", value: "1", want: "https://example.com/path?a=1"},
+		{name: "forced empty query", in: "https://example.com/path?", key: "a", value: "1", want: "https://example.com/path?a=1"},
+		{name: "existing query", in: "https://example.com/path?a=1#frag", key: "b", value: "2", want: "https://example.com/path?a=1&b=2#frag"},
+		{name: "existing query is not encoded again", in: "https://example.com?x=%20", key: "b", value: "%20", want: "https://example.com?x=%20&b=%2520"},
+		{name: "delimiters are escaped", in: "https://example.com", key: "a&b=c", value: "d&e=f+g h#i", want: "https://example.com?a%26b%3Dc=d%26e%3Df%2Bg%20h%23i"},
+		{name: "non-ASCII is kept", in: "https://example.com", key: "µ", value: "€", want: "https://example.com?µ=€"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got := value.AppendQueryParam(tc.key, tc.value)
+			if got.String() != tc.want {
+				t.Errorf("AppendQueryParam() = %q, want %q", got, tc.want)
+			}
+			values, err := got.QueryValues()
+			if err != nil {
+				t.Fatalf("QueryValues() returned error: %v", err)
+			}
+			if gotValues := values[tc.key]; (len(gotValues) == 0) || (gotValues[len(gotValues)-1] != tc.value) {
+				t.Errorf("QueryValues()[%q] = %v, want last value %q", tc.key, gotValues, tc.value)
+			}
+		})
+	}
+}
+
+func TestWithQueryParams(t *testing.T) {
+	tt := []struct {
+		name    string
+		in      string
+		pairs   []string
+		want    string
+		wantErr bool
+	}{
+		{name: "two pairs", in: "https://example.com/path#frag", pairs: []string{"b", "2", "a", "1"}, want: "https://example.com/path?b=2&a=1#frag"},
+		{name: "replaces existing query", in: "https://example.com?x=1", pairs: []string{"a", "1"}, want: "https://example.com?a=1"},
+		{name: "escapes keys and values", in: "https://example.com", pairs: []string{"a&b", "c=d e"}, want: "https://example.com?a%26b=c%3Dd%20e"},
+		{name: "repeated key", in: "https://example.com", pairs: []string{"a", "1", "a", "2"}, want: "https://example.com?a=1&a=2"},
+		{name: "no pairs removes query", in: "https://example.com?x=1", pairs: nil, want: "https://example.com"},
+		{name: "no pairs removes forced query", in: "https://example.com?", pairs: nil, want: "https://example.com"},
+		{name: "odd count", in: "https://example.com", pairs: []string{"a", "1", "b"}, wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, err := value.WithQueryParams(tc.pairs...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("WithQueryParams() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithForceQuery(t *testing.T) {
+	t.Parallel()
+	value, err := iri.IRI{Scheme: "https", Authority: "example.com"}.WithQueryParams()
+	if err != nil {
+		t.Fatalf("WithQueryParams() returned error: %v", err)
+	}
+	if got, want := value.WithForceQuery().String(), "https://example.com?"; got != want {
+		t.Errorf("WithForceQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestSortQuery(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "https://example.com?b=2&a=1", want: "https://example.com?a=1&b=2"},
+		{in: "https://example.com?b=2&a=3&a=1&a=2#frag", want: "https://example.com?a=1&a=2&a=3&b=2#frag"},
+		{in: "https://example.com?a&a=&a=1", want: "https://example.com?a&a=&a=1"},
+		{in: "https://example.com?b=1&%61=%7e&a=1", want: "https://example.com?a=1&%61=%7e&b=1"},
+		{in: "https://example.com?b=1&%61=2", want: "https://example.com?%61=2&b=1"},
+		{in: "https://example.com?b+c=1&b%20c=0", want: "https://example.com?b%20c=0&b+c=1"},
+		{in: "https://example.com?", want: "https://example.com?"},
+		{in: "https://example.com", want: "https://example.com"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.SortQuery(); got.String() != tc.want {
+				t.Errorf("SortQuery() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRemoveQueryParams(t *testing.T) {
+	tt := []struct {
+		in    string
+		names []string
+		want  string
+	}{
+		{in: "https://example.com/?id=1&utm_source=news&utm_medium=email&b=2", names: []string{"utm_source", "utm_medium"}, want: "https://example.com/?id=1&b=2"},
+		{in: "https://example.com/?utm_source=news&utm_medium=email#f", names: []string{"utm_source", "utm_medium"}, want: "https://example.com/#f"},
+		{in: "https://example.com/?utm_source=a&x=1&utm_source=b", names: []string{"utm_source"}, want: "https://example.com/?x=1"},
+		{in: "https://example.com/?utm%5Fsource=a&x=1&utm_source", names: []string{"utm_source"}, want: "https://example.com/?x=1"},
+		{in: "https://example.com/?UTM_SOURCE=a", names: []string{"utm_source"}, want: "https://example.com/?UTM_SOURCE=a"},
+		{in: "https://example.com/?a=1&&b=2", names: []string{"a"}, want: "https://example.com/?&b=2"},
+		{in: "https://example.com/?", names: []string{"a"}, want: "https://example.com/?"},
+		{in: "https://example.com/", names: []string{"a"}, want: "https://example.com/"},
+		{in: "https://example.com/?a=%7e", names: nil, want: "https://example.com/?a=%7e"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.RemoveQueryParams(tc.names...); got.String() != tc.want {
+				t.Errorf("RemoveQueryParams(%q, %v) = %q, want %q", tc.in, tc.names, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRemoveQueryParamsMatching(t *testing.T) {
+	t.Parallel()
+	value, err := iri.Parse("https://example.com/?utm_source=a&id=1&utm_campaign=b&utm=c")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	got := value.RemoveQueryParamsMatching(func(key string) bool { return strings.HasPrefix(key, "utm_") })
+	if want := "https://example.com/?id=1&utm=c"; got.String() != want {
+		t.Errorf("RemoveQueryParamsMatching() = %q, want %q", got, want)
+	}
+	if value.Query != "utm_source=a&id=1&utm_campaign=b&utm=c" {
+		t.Errorf("RemoveQueryParamsMatching() modified the original query to %q", value.Query)
+	}
+}
@@ -0,0 +1,195 @@
+package iri
+
+import (
+	"fmt"
+	"math/rand"
+	"net/netip"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// randomSize is the size of the components of IRIs created by Rand.
+const randomSize = 10
+
+// Rand returns a random, valid IRI. The same source of randomness produces the same IRIs.
+//
+// The IRIs cover all combinations of present, absent, and empty components, including
+// the Force* flags, as well as non-ASCII characters and percent-encoding. All of them pass
+// Validate, and their string is parsed into the same IRI. This is meant for property-based
+// tests and fuzzing of code that handles IRIs.
+func Rand(r *rand.Rand) IRI {
+	return randomGenerator{r: r, size: randomSize}.iri()
+}
+
+// Generate implements the testing/quick.Generator interface. It returns a random IRI like Rand,
+// with size limiting the number of characters and segments of each component.
+func (IRI) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomGenerator{r: r, size: size}.iri())
+}
+
+type randomGenerator struct {
+	r    *rand.Rand
+	size int
+}
+
+const (
+	randomUnreserved = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-._~"
+	randomSubDelims  = "!$&'()*+,;="
+	randomSchemeRest = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789+-."
+)
+
+// randomUcsRanges are some of the ranges of ucschar, see RFC 3987 section 2.2.
+var randomUcsRanges = [][2]rune{{0xA0, 0xD7FF}, {0xF900, 0xFDCF}, {0xFDF0, 0xFFEF}, {0x10000, 0x1FFFD}}
+
+func (g randomGenerator) iri() IRI {
+	var result IRI
+	if g.chance() {
+		result.Scheme = g.scheme()
+	}
+	hasAuthority := g.chance()
+	if hasAuthority {
+		result.Authority = g.authority()
+		result.ForceAuthority = result.Authority == ""
+	}
+	result.Path = g.path(hasAuthority, result.Scheme != "")
+	if g.chance() {
+		result.Query = g.text(":@/?", true)
+		result.ForceQuery = result.Query == ""
+	}
+	if g.chance() {
+		result.Fragment = g.text(":@/?", false)
+		result.ForceFragment = result.Fragment == ""
+	}
+	return result
+}
+
+func (g randomGenerator) chance() bool {
+	return g.r.Intn(2) == 0
+}
+
+func (g randomGenerator) length() int {
+	if g.size <= 0 {
+		return 0
+	}
+	return g.r.Intn(g.size + 1)
+}
+
+func (g randomGenerator) pick(chars string) byte {
+	return chars[g.r.Intn(len(chars))]
+}
+
+func (g randomGenerator) scheme() string {
+	var result strings.Builder
+	result.WriteByte(g.pick(randomUnreserved[:52]))
+	for i := g.length(); i > 0; i-- {
+		result.WriteByte(g.pick(randomSchemeRest))
+	}
+	return result.String()
+}
+
+func (g randomGenerator) authority() string {
+	var parts authorityParts
+	if g.chance() {
+		parts.userInfo, parts.hasUserInfo = g.text(":", false), true
+	}
+	switch g.r.Intn(4) {
+	case 0:
+		parts.host = fmt.Sprintf("%d.%d.%d.%d", g.r.Intn(256), g.r.Intn(256), g.r.Intn(256), g.r.Intn(256))
+	case 1:
+		var address [16]byte
+		g.r.Read(address[:])
+		parts.host = "[" + netip.AddrFrom16(address).String() + "]"
+	case 2:
+		var future strings.Builder
+		future.WriteString("[v")
+		future.WriteByte(g.pick("0123456789abcdefABCDEF"))
+		future.WriteByte('.')
+		for i := g.length() + 1; i > 0; i-- {
+			future.WriteByte(g.pick(randomUnreserved + randomSubDelims + ":"))
+		}
+		future.WriteByte(']')
+		parts.host = future.String()
+	default:
+		parts.host = g.text("", false)
+	}
+	if g.chance() {
+		var port strings.Builder
+		for i := g.r.Intn(6); i > 0; i-- {
+			port.WriteByte(g.pick("0123456789"))
+		}
+		parts.port, parts.hasPort = port.String(), true
+	}
+	return parts.String()
+}
+
+// path returns a path that complies with the constraints of the other components.
+// See IRI.validateStructure.
+func (g randomGenerator) path(hasAuthority, hasScheme bool) string {
+	segmentCount := g.length()
+	if segmentCount == 0 {
+		return ""
+	}
+	segments := make([]string, segmentCount)
+	for i := range segments {
+		segments[i] = g.text(":@", false)
+	}
+	if hasAuthority {
+		return "/" + strings.Join(segments, "/")
+	}
+	absolute := g.chance()
+	for segments[0] == "" {
+		segments[0] = g.text(":@", false)
+	}
+	if !hasScheme && !absolute {
+		segments[0] = strings.ReplaceAll(segments[0], ":", "%3A")
+	}
+	if absolute {
+		return "/" + strings.Join(segments, "/")
+	}
+	return strings.Join(segments, "/")
+}
+
+// text returns a string of characters that are valid in any component, with the given
+// extra characters. Characters of the iprivate production are only included if allowed.
+func (g randomGenerator) text(extra string, allowPrivate bool) string {
+	var result strings.Builder
+	for i := g.length(); i > 0; i-- {
+		switch g.r.Intn(5) {
+		case 0:
+			result.WriteRune(g.ucschar())
+		case 1:
+			result.WriteByte(g.pick(randomSubDelims + extra))
+		case 2:
+			r := g.ucschar()
+			if g.chance() {
+				r = rune(g.r.Intn(utf8.RuneSelf))
+			}
+			result.WriteString(escapeRune(r))
+		case 3:
+			if allowPrivate {
+				result.WriteRune(0xE000 + rune(g.r.Intn(0xF8FF-0xE000+1)))
+				break
+			}
+			fallthrough
+		default:
+			result.WriteByte(g.pick(randomUnreserved))
+		}
+	}
+	return result.String()
+}
+
+func (g randomGenerator) ucschar() rune {
+	r := randomUcsRanges[g.r.Intn(len(randomUcsRanges))]
+	return r[0] + rune(g.r.Intn(int(r[1]-r[0]+1)))
+}
+
+func escapeRune(r rune) string {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	var result strings.Builder
+	for _, b := range buf[:n] {
+		fmt.Fprintf(&result, "%%%02X", b)
+	}
+	return result.String()
+}
@@ -0,0 +1,60 @@
+package iri_test
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+	"unicode/utf8"
+
+	"github.com/contomap/iri"
+)
+
+func TestRandIsValidAndRoundTrips(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	var forcedAuthority, forcedQuery, forcedFragment, nonASCII, relative bool
+	for i := 0; i < 2000; i++ {
+		value := iri.Rand(r)
+		if err := value.Validate(); err != nil {
+			t.Fatalf("Validate() of %#v returned error: %v", value, err)
+		}
+		parsed, err := iri.Parse(value.String())
+		if err != nil {
+			t.Fatalf("Parse() returned error: %v", err)
+		}
+		if parsed != value {
+			t.Fatalf("Parse(%q) = %#v, want %#v", value, parsed, value)
+		}
+		forcedAuthority = forcedAuthority || value.ForceAuthority
+		forcedQuery = forcedQuery || value.ForceQuery
+		forcedFragment = forcedFragment || value.ForceFragment
+		nonASCII = nonASCII || (utf8.RuneCountInString(value.String()) != len(value.String()))
+		relative = relative || (value.Scheme == "")
+	}
+	if !forcedAuthority || !forcedQuery || !forcedFragment || !nonASCII || !relative {
+		t.Errorf("Rand() does not cover all cases: forced authority %v, query %v, fragment %v, non-ASCII %v, relative %v",
+			forcedAuthority, forcedQuery, forcedFragment, nonASCII, relative)
+	}
+}
+
+func TestRandIsDeterministic(t *testing.T) {
+	t.Parallel()
+	a := rand.New(rand.NewSource(42))
+	b := rand.New(rand.NewSource(42))
+	for i := 0; i < 100; i++ {
+		if x, y := iri.Rand(a), iri.Rand(b); x != y {
+			t.Fatalf("Rand() with equal sources differs: %#v, %#v", x, y)
+		}
+	}
+}
+
+func TestGenerateWithQuick(t *testing.T) {
+	t.Parallel()
+	roundTrips := func(value iri.IRI) bool {
+		parsed, err := iri.Parse(value.String())
+		return (err == nil) && (parsed == value) && (value.Validate() == nil)
+	}
+	if err := quick.Check(roundTrips, &quick.Config{MaxCount: 500, Rand: rand.New(rand.NewSource(7))}); err != nil {
+		t.Error(err)
+	}
+}
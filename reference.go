@@ -0,0 +1,73 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsOpaque returns true if the IRI has a scheme, no authority, and a non-empty path
+// that does not begin with a slash ('/').
+//
+// Such IRIs, for example "mailto:user@example.com", "about:blank", or "urn:isbn:0451450523",
+// have no hierarchical path. This matches the concept of an opaque "net/url.URL".
+func (iri IRI) IsOpaque() bool {
+	return iri.hasScheme() && !iri.hasAuthority() && (iri.Path != "") && !strings.HasPrefix(iri.Path, "/")
+}
+
+// ReferenceKind classifies an IRI reference as per RFC 3986, sections 4.2 to 4.4.
+type ReferenceKind int
+
+// These are the known reference kinds.
+const (
+	// ReferenceKindEmpty is for the empty reference "", which refers to the current document.
+	ReferenceKindEmpty ReferenceKind = iota
+	// ReferenceKindSameDocument is for references that consist only of a fragment, such as "#s".
+	ReferenceKindSameDocument
+	// ReferenceKindAbsolute is for references with a scheme, such as "g:h".
+	ReferenceKindAbsolute
+	// ReferenceKindNetworkPath is for relative references with an authority, such as "//g".
+	ReferenceKindNetworkPath
+	// ReferenceKindAbsolutePath is for relative references with a path that begins with a slash, such as "/g".
+	ReferenceKindAbsolutePath
+	// ReferenceKindRelativePath is for all other relative references, such as "g", "../g", or "?y".
+	ReferenceKindRelativePath
+)
+
+// ReferenceKind returns the kind of the IRI, when used as a reference.
+func (iri IRI) ReferenceKind() ReferenceKind {
+	switch {
+	case iri.hasScheme():
+		return ReferenceKindAbsolute
+	case iri.hasAuthority():
+		return ReferenceKindNetworkPath
+	case strings.HasPrefix(iri.Path, "/"):
+		return ReferenceKindAbsolutePath
+	case (iri.Path != "") || iri.hasQuery():
+		return ReferenceKindRelativePath
+	case iri.hasFragment():
+		return ReferenceKindSameDocument
+	default:
+		return ReferenceKindEmpty
+	}
+}
+
+// ParseRelativePath parses a string into an IRI reference that has neither a scheme nor an authority.
+//
+// Such references are of kind ReferenceKindAbsolutePath, ReferenceKindRelativePath,
+// ReferenceKindSameDocument, or ReferenceKindEmpty.
+// A string that begins with two slashes ("//") would be parsed as network-path reference
+// with an authority, and is rejected instead. Similarly, a string whose first segment
+// contains a colon would be parsed as IRI with a scheme, and is rejected.
+func ParseRelativePath(s string, opts ...ParseOption) (IRI, error) {
+	if strings.HasPrefix(s, "//") {
+		return IRI{}, fmt.Errorf("%q is not a valid relative path: it begins with two slashes and would be read as authority", s)
+	}
+	parsed, err := Parse(s, opts...)
+	if err != nil {
+		return IRI{}, err
+	}
+	if parsed.hasScheme() {
+		return IRI{}, fmt.Errorf("%q is not a valid relative path: it would be read as scheme %q", s, parsed.Scheme)
+	}
+	return parsed, nil
+}
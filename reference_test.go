@@ -0,0 +1,129 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestIsOpaque(t *testing.T) {
+	tt := []struct {
+		in   string
+		want bool
+	}{
+		{in: "mailto:a@b", want: true},
+		{in: "about:blank", want: true},
+		{in: "urn:uuid:6c689097-8097-4421-9def-05e835f2dbb8", want: true},
+		{in: "javascript:void(0)", want: true},
+		{in: "http://h/p", want: false},
+		{in: "file:///p", want: false},
+		{in: "file:/p", want: false},
+		{in: "urn:", want: false},
+		{in: "relative/path", want: false},
+		{in: "", want: false},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.IsOpaque(); got != tc.want {
+				t.Errorf("IsOpaque() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReferenceKindRFC3986Samples(t *testing.T) {
+	tt := []struct {
+		ref  string
+		want iri.ReferenceKind
+	}{
+		{ref: "g:h", want: iri.ReferenceKindAbsolute},
+		{ref: "http:g", want: iri.ReferenceKindAbsolute},
+		{ref: "http://a/b/c/d;p?q", want: iri.ReferenceKindAbsolute},
+		{ref: "//g", want: iri.ReferenceKindNetworkPath},
+		{ref: "//", want: iri.ReferenceKindNetworkPath},
+		{ref: "/g", want: iri.ReferenceKindAbsolutePath},
+		{ref: "/./g", want: iri.ReferenceKindAbsolutePath},
+		{ref: "/../g", want: iri.ReferenceKindAbsolutePath},
+		{ref: "g", want: iri.ReferenceKindRelativePath},
+		{ref: "./g", want: iri.ReferenceKindRelativePath},
+		{ref: "g/", want: iri.ReferenceKindRelativePath},
+		{ref: "?y", want: iri.ReferenceKindRelativePath},
+		{ref: "?", want: iri.ReferenceKindRelativePath},
+		{ref: "g?y#s", want: iri.ReferenceKindRelativePath},
+		{ref: ";x", want: iri.ReferenceKindRelativePath},
+		{ref: ".", want: iri.ReferenceKindRelativePath},
+		{ref: "..", want: iri.ReferenceKindRelativePath},
+		{ref: "../../../g", want: iri.ReferenceKindRelativePath},
+		{ref: "#s", want: iri.ReferenceKindSameDocument},
+		{ref: "#", want: iri.ReferenceKindSameDocument},
+		{ref: "", want: iri.ReferenceKindEmpty},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.ref, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.ref)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.ReferenceKind(); got != tc.want {
+				t.Errorf("ReferenceKind() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseDoubleSlashIsAuthority(t *testing.T) {
+	t.Parallel()
+	got, err := iri.Parse("//foo")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if (got.Authority != "foo") || (got.Path != "") {
+		t.Errorf("Parse(%q) = %#v, want authority %q", "//foo", got, "foo")
+	}
+}
+
+func TestParseRelativePath(t *testing.T) {
+	tt := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: ""},
+		{in: "g"},
+		{in: "./g:h"},
+		{in: "/g"},
+		{in: "/a//b"},
+		{in: "a//b"},
+		{in: "?y#s"},
+		{in: "#s"},
+		{in: "//foo", wantErr: true},
+		{in: "//", wantErr: true},
+		{in: "///foo", wantErr: true},
+		{in: "g:h", wantErr: true},
+		{in: "http://a/b", wantErr: true},
+		{in: "a b", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.ParseRelativePath(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && (got.String() != tc.in) {
+				t.Errorf("ParseRelativePath(%q) = %q", tc.in, got)
+			}
+		})
+	}
+}
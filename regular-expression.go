@@ -76,7 +76,11 @@ const (
 )
 
 const (
-	ipLiteral = `\[(?:` + ipV6Address + `|` + ipVFuture + `)\]`
+	ipLiteral = `\[(?:` + ipV6Addrz + `|` + ipVFuture + `)\]`
+
+	// IPv6 address with optional zone identifier, as per RFC 6874.
+	// The percent sign that separates the zone identifier must be percent-encoded.
+	ipV6Addrz = ipV6Address + `(?:%25(?:` + unreserved + `|` + pctEncoded + `)+)?`
 
 	ipVFuture = `v` + hex + `\.(?:` + unreserved + `|` + subDelims + `|\:)*`
 
@@ -95,7 +99,7 @@ const (
 
 	h16         = `(?:` + hex + `{1,4})`
 	ls32        = `(?:` + h16 + `\:` + h16 + `|` + ipV4Address + `)`
-	ipV4Address = `(?:` + decOctet + `.` + decOctet + `.` + decOctet + `.` + decOctet + `)`
+	ipV4Address = `(?:` + decOctet + `\.` + decOctet + `\.` + decOctet + `\.` + decOctet + `)`
 
 	decOctet = `(?:` +
 		`\d` + `|` + // 0-9
@@ -115,9 +119,17 @@ var (
 
 	pctEncodedCharOneOrMore = mustCompileNamed("pctEncodedOneOrMore", pctEncodedOneOrMore)
 	iunreservedRE           = mustCompileNamed("iunreservedRE", "^"+iunreserved+"$")
+	iprivateRE              = mustCompileNamed("iprivateRE", "^"+iprivate+"$")
+	ipLiteralRE             = mustCompileNamed("ipLiteralRE", "^"+ipLiteral+"$")
+	ipV4AddressRE           = mustCompileNamed("ipV4AddressRE", "^"+ipV4Address+"$")
+	ipV6AddressRE           = mustCompileNamed("ipV6AddressRE", "^"+ipV6Address+"$")
+	ipV6AddrzRE             = mustCompileNamed("ipV6AddrzRE", "^"+ipV6Addrz+"$")
+	ipVFutureRE             = mustCompileNamed("ipVFutureRE", "^"+ipVFuture+"$")
 
 	// Regular expression from RFC 3986 page 50.
-	uriRE                             = mustCompileNamed("uriRE", `^(([^:/?#]+):)?(//([^/?#]*))?([^?#]*)(\?([^#]*))?(#(.*))?`)
+	// The flag "s" lets the fragment extend to the end of the input, even across line breaks,
+	// so that no part of the input is ignored.
+	uriRE                             = mustCompileNamed("uriRE", `(?s)^(([^:/?#]+):)?(//([^/?#]*))?([^?#]*)(\?([^#]*))?(#(.*))?`)
 	uriRESchemeGroup                  = 2
 	uriREAuthorityWithSlashSlashGroup = 3
 	uriREAuthorityGroup               = 4
@@ -135,3 +147,27 @@ func mustCompileNamed(name, expr string) *regexp.Regexp {
 	}
 	return c
 }
+
+// patterns lists the component validators that are available through Pattern.
+var patterns = map[string]*regexp.Regexp{
+	"scheme":      schemeRE,
+	"authority":   iauthorityRE,
+	"path":        ipathRE,
+	"query":       iqueryRE,
+	"fragment":    ifragmentRE,
+	"iunreserved": iunreservedRE,
+}
+
+// Pattern returns the regular expression that this package uses to validate the named
+// grammar rule of RFC 3987. Known names are "scheme", "authority", "path", "query", "fragment",
+// and "iunreserved". The second return value is false for any other name.
+//
+// All patterns are anchored with "^" and "$"; They match complete strings only.
+// The returned regular expression is a fresh copy, which the caller may modify freely.
+func Pattern(name string) (*regexp.Regexp, bool) {
+	re, known := patterns[name]
+	if !known {
+		return nil, false
+	}
+	return regexp.MustCompile(re.String()), true
+}
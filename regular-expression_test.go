@@ -40,6 +40,24 @@ func TestRegExps(t *testing.T) {
 			in:   "\u00FE",
 			want: true,
 		},
+		{
+			name: "dotted-decimal is an IPv4 address",
+			re:   ipV4AddressRE,
+			in:   "1.2.3.4",
+			want: true,
+		},
+		{
+			name: "octets must be separated by dots",
+			re:   ipV4AddressRE,
+			in:   "1x2x3x4",
+			want: false,
+		},
+		{
+			name: "octets must not exceed 255",
+			re:   ipV4AddressRE,
+			in:   "999.1.1.1",
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -50,3 +68,52 @@ func TestRegExps(t *testing.T) {
 		})
 	}
 }
+
+func TestPatternsAreAnchored(t *testing.T) {
+	for name, re := range patterns {
+		expr := re.String()
+		if !strings.HasPrefix(expr, "^") || !strings.HasSuffix(expr, "$") {
+			t.Errorf("pattern %q is not anchored: %s", name, expr)
+		}
+	}
+}
+
+func TestPattern(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		wantKnown bool
+		wantMatch bool
+	}{
+		{name: "scheme", in: "http", wantKnown: true, wantMatch: true},
+		{name: "scheme", in: "1http", wantKnown: true, wantMatch: false},
+		{name: "authority", in: "user@example.com:8080", wantKnown: true, wantMatch: true},
+		{name: "path", in: "/a/b", wantKnown: true, wantMatch: true},
+		{name: "query", in: "a=b", wantKnown: true, wantMatch: true},
+		{name: "fragment", in: "a#b", wantKnown: true, wantMatch: false},
+		{name: "iunreserved", in: "þ", wantKnown: true, wantMatch: true},
+		{name: "iunreserved", in: "ab", wantKnown: true, wantMatch: false},
+		{name: "unknown", wantKnown: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.in, func(t *testing.T) {
+			re, known := Pattern(tt.name)
+			if known != tt.wantKnown {
+				t.Fatalf("Pattern(%q) known = %v, want %v", tt.name, known, tt.wantKnown)
+			}
+			if !known {
+				return
+			}
+			if got := re.MatchString(tt.in); got != tt.wantMatch {
+				t.Errorf("%s.Match(%q) got %v, want %v", re, tt.in, got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestPatternReturnsCopy(t *testing.T) {
+	re, _ := Pattern("scheme")
+	if re == schemeRE {
+		t.Errorf("Pattern returned the internal regular expression")
+	}
+}
@@ -0,0 +1,27 @@
+package iri
+
+import "fmt"
+
+// MaxResolveChainHops is the maximum number of references that ResolveChain resolves.
+const MaxResolveChainHops = 20
+
+// ResolveChain resolves each of the given references against the result of the previous
+// resolution, starting with the base IRI. This follows a chain of redirects, such as given
+// by consecutive "Location" headers of HTTP responses.
+//
+// It returns an error if any reference is not a valid IRI, or if there are more than
+// MaxResolveChainHops references.
+func ResolveChain(base IRI, refs []string) (IRI, error) {
+	if len(refs) > MaxResolveChainHops {
+		return IRI{}, fmt.Errorf("resolve chain of %d references exceeds the limit of %d", len(refs), MaxResolveChainHops)
+	}
+	current := base
+	for i, raw := range refs {
+		ref, err := Parse(raw)
+		if err != nil {
+			return IRI{}, fmt.Errorf("reference %d of resolve chain: %w", i, err)
+		}
+		current = current.ResolveReference(ref)
+	}
+	return current, nil
+}
@@ -0,0 +1,40 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestResolveChain(t *testing.T) {
+	tt := []struct {
+		name    string
+		refs    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "no references", refs: nil, want: "http://example.com/x/y/z"},
+		{name: "relative chain", refs: []string{"/a", "b", "../c"}, want: "http://example.com/c"},
+		{name: "nested relative chain", refs: []string{"/a/b/", "c/d", "../e?q#f"}, want: "http://example.com/a/b/e?q#f"},
+		{name: "absolute in between", refs: []string{"/a", "https://other.example/p/q", "r"}, want: "https://other.example/p/r"},
+		{name: "network-path", refs: []string{"//other.example/p", "q"}, want: "http://other.example/q"},
+		{name: "malformed reference", refs: []string{"/a", "%zz"}, wantErr: true},
+		{name: "too many hops", refs: make([]string, iri.MaxResolveChainHops+1), wantErr: true},
+		{name: "at hop limit", refs: make([]string, iri.MaxResolveChainHops), want: "http://example.com/x/y/z"},
+	}
+	base := iri.IRI{Scheme: "http", Authority: "example.com", Path: "/x/y/z"}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.ResolveChain(base, tc.refs)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("ResolveChain() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
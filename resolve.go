@@ -33,11 +33,15 @@ package iri
 // (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
 // OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 
-import "strings"
+import (
+	"bytes"
+	"strings"
+)
 
 func resolveReference(base, ref IRI) IRI {
 	result := ref
 	if ref.hasScheme() {
+		result.Path = removeHierarchicalDotSegments(ref.Path)
 		return result
 	}
 	result.Scheme = base.Scheme
@@ -45,9 +49,16 @@ func resolveReference(base, ref IRI) IRI {
 		result.Path = resolvePath(ref.Path, "")
 		return result
 	}
+	if base.IsOpaque() && (ref.Path != "") {
+		return base.withoutFragment()
+	}
 	result.ForceAuthority = base.ForceAuthority
 	result.Authority = base.Authority
-	result.Path = resolvePath(base.Path, ref.Path)
+	if base.IsOpaque() {
+		result.Path = base.Path
+	} else {
+		result.Path = resolvePath(base.Path, ref.Path)
+	}
 	if ref.hasQuery() || (ref.Path != "") {
 		return result
 	}
@@ -56,6 +67,58 @@ func resolveReference(base, ref IRI) IRI {
 	return result
 }
 
+// baseResolver resolves references against a fixed base. It determines the directory
+// and the resolved path of the base only once.
+type baseResolver struct {
+	base      IRI
+	opaque    bool
+	directory string
+	path      string
+}
+
+func newBaseResolver(base IRI) baseResolver {
+	if base.IsOpaque() {
+		return baseResolver{base: base, opaque: true, path: base.Path}
+	}
+	return baseResolver{
+		base:      base,
+		directory: base.Path[:strings.LastIndex(base.Path, "/")+1],
+		path:      removeDotSegments(base.Path),
+	}
+}
+
+func (r baseResolver) resolve(ref IRI) IRI {
+	result := ref
+	if ref.hasScheme() {
+		result.Path = removeHierarchicalDotSegments(ref.Path)
+		return result
+	}
+	result.Scheme = r.base.Scheme
+	if ref.hasAuthority() {
+		result.Path = removeDotSegments(ref.Path)
+		return result
+	}
+	if r.opaque && (ref.Path != "") {
+		return r.base.withoutFragment()
+	}
+	result.ForceAuthority = r.base.ForceAuthority
+	result.Authority = r.base.Authority
+	switch {
+	case ref.Path == "":
+		result.Path = r.path
+	case ref.Path[0] != '/':
+		result.Path = removeDotSegments(r.directory + ref.Path)
+	default:
+		result.Path = removeDotSegments(ref.Path)
+	}
+	if ref.hasQuery() || (ref.Path != "") {
+		return result
+	}
+	result.ForceQuery = r.base.ForceQuery
+	result.Query = r.base.Query
+	return result
+}
+
 // resolvePath applies special path segments from refs and applies
 // them to base, per RFC 3986.
 func resolvePath(base, ref string) string {
@@ -69,16 +132,32 @@ func resolvePath(base, ref string) string {
 	default:
 		full = ref
 	}
+	return removeDotSegments(full)
+}
+
+// removeHierarchicalDotSegments removes the dot segments from a path that begins with a slash.
+// Other paths, such as those of opaque IRIs, are returned as they are.
+func removeHierarchicalDotSegments(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return path
+	}
+	return removeDotSegments(path)
+}
+
+// removeDotSegments removes the special path segments "." and ".." from the given path, per RFC 3986.
+func removeDotSegments(full string) string {
 	if full == "" {
 		return ""
 	}
 
+	// The resolved path is built in a byte slice that is truncated in place for each "..".
+	// As every removed byte was written exactly once, this is linear in the length of the path.
 	var (
 		last string
 		elem string
 		i    int
-		dst  strings.Builder
 	)
+	dst := make([]byte, 0, len(full)+1)
 	first := true
 	remaining := full
 	for i >= 0 {
@@ -95,27 +174,25 @@ func resolvePath(base, ref string) string {
 		}
 
 		if elem == ".." {
-			str := dst.String()
-			index := strings.LastIndexByte(str, '/')
-
-			dst.Reset()
+			index := bytes.LastIndexByte(dst, '/')
 			if index == -1 {
+				dst = dst[:0]
 				first = true
 			} else {
-				dst.WriteString(str[:index])
+				dst = dst[:index]
 			}
 		} else {
 			if !first {
-				dst.WriteByte('/')
+				dst = append(dst, '/')
 			}
-			dst.WriteString(elem)
+			dst = append(dst, elem...)
 			first = false
 		}
 	}
 
 	if last == "." || last == ".." {
-		dst.WriteByte('/')
+		dst = append(dst, '/')
 	}
 
-	return "/" + strings.TrimPrefix(dst.String(), "/")
+	return "/" + strings.TrimPrefix(string(dst), "/")
 }
@@ -0,0 +1,256 @@
+package iri_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func BenchmarkResolveReferenceDotSegments(b *testing.B) {
+	deepBase := iri.IRI{Scheme: "http", Authority: "a", Path: "/" + strings.Repeat("b/", 10000)}
+	benchmarks := []struct {
+		name string
+		base iri.IRI
+		ref  iri.IRI
+	}{
+		{name: "common", base: iri.IRI{Scheme: "http", Authority: "a", Path: "/b/c/d;p", Query: "q"}, ref: iri.IRI{Path: "../g"}},
+		{name: "deep base", base: deepBase, ref: iri.IRI{Path: "../g"}},
+		{name: "10000 parents", base: deepBase, ref: iri.IRI{Path: strings.Repeat("../", 10000) + "g"}},
+		{name: "10000 segments", base: deepBase, ref: iri.IRI{Path: strings.Repeat("./c/", 10000)}},
+	}
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = bm.base.ResolveReference(bm.ref)
+			}
+		})
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	bases := []string{
+		"http://a/b/c/d;p?q",
+		"http://a/b/c/d;p?q#f",
+		"http://a",
+		"http://a?q",
+		"http://a/b/./c/../d",
+		"urn:a:b",
+		"foo:",
+		"",
+	}
+	refs := make([]iri.IRI, 0, len(rfcSamples))
+	for _, sample := range rfcSamples {
+		if ref, err := iri.Parse(sample); err == nil {
+			refs = append(refs, ref)
+		}
+	}
+	t.Parallel()
+	for _, base := range bases {
+		base := base
+		t.Run(base, func(t *testing.T) {
+			t.Parallel()
+			baseIRI, err := iri.Parse(base)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got := baseIRI.ResolveAll(refs)
+			if len(got) != len(refs) {
+				t.Fatalf("ResolveAll() returned %d IRIs, want %d", len(got), len(refs))
+			}
+			for i, ref := range refs {
+				if want := baseIRI.ResolveReference(ref); got[i] != want {
+					t.Errorf("ResolveAll() of %q got %#v, want %#v", ref, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveAllEmpty(t *testing.T) {
+	t.Parallel()
+	base := iri.IRI{Scheme: "http", Authority: "a"}
+	if got := base.ResolveAll(nil); len(got) != 0 {
+		t.Errorf("ResolveAll(nil) got %v, want empty", got)
+	}
+}
+
+func BenchmarkResolveAll(b *testing.B) {
+	base := iri.IRI{Scheme: "http", Authority: "example.com", Path: "/docs/" + strings.Repeat("section/", 20) + "index.html", Query: "lang=en"}
+	refs := make([]iri.IRI, 0, 300)
+	for i := 0; i < 100; i++ {
+		refs = append(refs, iri.IRI{Path: "../other.html"}, iri.IRI{Fragment: "anchor"}, iri.IRI{Path: "image.png"})
+	}
+	b.Run("ResolveReference", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, ref := range refs {
+				_ = base.ResolveReference(ref)
+			}
+		}
+	})
+	b.Run("ResolveAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = base.ResolveAll(refs)
+		}
+	})
+}
+
+// FuzzResolveReferenceAgainstNetURL compares the resolution of references with that of "net/url".
+//
+// Only ASCII inputs without percent-encoding are compared, as "net/url" may change the encoding of paths.
+// The base must be an http or https IRI with a host. These are the intentional differences, which are skipped:
+//   - "net/url" keeps the fragment of the base for an empty reference, as per RFC 1808.
+//     RFC 3986 Section 5.2.2 takes the fragment of the reference only.
+//   - "net/url" removes dot segments differently if they follow empty segments, as in "/a//../b".
+//     This package follows the algorithm of RFC 3986 Section 5.2.4.
+//   - "net/url" converts the scheme to lowercase, while this package keeps it as it is.
+//   - "net/url" does not take an empty query of the base, as in "http://a/b?", for an empty reference.
+//   - "net/url" cannot represent an empty fragment, as in "http://a/b#", nor an empty authority, as in "http:///b" or "//".
+func FuzzResolveReferenceAgainstNetURL(f *testing.F) {
+	for _, sample := range rfcSamples {
+		f.Add("http://a/b/c/d;p?q", sample)
+		f.Add("https://a/b/c/d;p?q#f", sample)
+	}
+	f.Fuzz(func(t *testing.T, rawBase, rawRef string) {
+		if !isComparableWithNetURL(rawBase) || !isComparableWithNetURL(rawRef) {
+			t.Skip()
+		}
+		base, errBase := iri.Parse(rawBase)
+		ref, errRef := iri.Parse(rawRef)
+		urlBase, errURLBase := url.Parse(rawBase)
+		urlRef, errURLRef := url.Parse(rawRef)
+		if (errBase != nil) || (errRef != nil) || (errURLBase != nil) || (errURLRef != nil) {
+			t.Skip()
+		}
+		if ((base.Scheme != "http") && (base.Scheme != "https")) || (base.Host() == "") {
+			t.Skip()
+		}
+		if base.ForceAuthority || ref.ForceAuthority || base.ForceQuery || (ref.Scheme != strings.ToLower(ref.Scheme)) || strings.Contains(base.Path+" "+ref.Path, "//") {
+			t.Skip()
+		}
+		if (ref.ReferenceKind() == iri.ReferenceKindEmpty) && ((base.Fragment != "") || base.ForceFragment) {
+			t.Skip()
+		}
+		got := base.ResolveReference(ref).String()
+		want := urlBase.ResolveReference(urlRef).String()
+		if got != want {
+			t.Errorf("ResolveReference(%q, %q) = %q, net/url resolves to %q", rawBase, rawRef, got, want)
+		}
+	})
+}
+
+// isComparableWithNetURL returns true for strings that "net/url" parses and serializes without changes.
+func isComparableWithNetURL(s string) bool {
+	if strings.HasSuffix(s, "#") {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-._~/?#=&;:", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestResolveReferenceRemovesDotSegmentsOfAbsoluteReference(t *testing.T) {
+	tt := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "a:/.", want: "a:/"},
+		{ref: "http://x/a/../b/./c", want: "http://x/b/c"},
+		{ref: "http:/a/../b", want: "http:/b"},
+		{ref: "http:g", want: "http:g"},
+		{ref: "urn:a:../b", want: "urn:a:../b"},
+	}
+	base := iri.IRI{Scheme: "http", Authority: "a", Path: "/b/c/d;p", Query: "q"}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.ref, func(t *testing.T) {
+			t.Parallel()
+			ref, err := iri.Parse(tc.ref)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := base.ResolveReference(ref); got.String() != tc.want {
+				t.Errorf("ResolveReference(%q) = %q, want %q", tc.ref, got, tc.want)
+			}
+			if got := base.ResolveAll([]iri.IRI{ref}); got[0].String() != tc.want {
+				t.Errorf("ResolveAll(%q) = %q, want %q", tc.ref, got[0], tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveReferenceKeepsEmptySegments(t *testing.T) {
+	tt := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "a//b", want: "http://a/b/c/a//b"},
+		{ref: "a//b/", want: "http://a/b/c/a//b/"},
+		{ref: "/a//b", want: "http://a/a//b"},
+		{ref: "x/..//y", want: "http://a/b/c//y"},
+		{ref: "./a//b/../c", want: "http://a/b/c/a//c"},
+		{ref: "g//../h", want: "http://a/b/c/g/h"},
+	}
+	base := iri.IRI{Scheme: "http", Authority: "a", Path: "/b/c/d"}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.ref, func(t *testing.T) {
+			t.Parallel()
+			ref, err := iri.Parse(tc.ref)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if ref.Path != tc.ref {
+				t.Errorf("Parse(%q).Path = %q, want %q", tc.ref, ref.Path, tc.ref)
+			}
+			if ref.String() != tc.ref {
+				t.Errorf("Parse().String() roundtrip failed: input: %q, output: %q", tc.ref, ref)
+			}
+			if got := base.ResolveReference(ref); got.String() != tc.want {
+				t.Errorf("ResolveReference(%q) = %q, want %q", tc.ref, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveReferenceInheritsUserInfo(t *testing.T) {
+	tt := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "c", want: "https://user@host/a/c"},
+		{ref: "/c", want: "https://user@host/c"},
+		{ref: "../c", want: "https://user@host/c"},
+		{ref: "?q", want: "https://user@host/a/b?q"},
+		{ref: "#f", want: "https://user@host/a/b#f"},
+		{ref: "", want: "https://user@host/a/b"},
+		{ref: "//other/x", want: "https://other/x"},
+		{ref: "//me@other/x", want: "https://me@other/x"},
+		{ref: "http://other/x", want: "http://other/x"},
+	}
+	base := iri.IRI{Scheme: "https", Authority: "user@host", Path: "/a/b"}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.ref, func(t *testing.T) {
+			t.Parallel()
+			ref, err := iri.Parse(tc.ref)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := base.ResolveReference(ref); got.String() != tc.want {
+				t.Errorf("ResolveReference(%q) = %q, want %q", tc.ref, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+// rfcSamples is a corpus of IRI references from the examples of RFC 3986 and RFC 3987.
+// Examples of RFC 3987 that use XML notation for non-ASCII characters are given with the actual characters.
+var rfcSamples = []string{
+	// RFC 3986, Section 1.1.2
+	"ftp://ftp.is.co.za/rfc/rfc1808.txt",
+	"http://www.ietf.org/rfc/rfc2396.txt",
+	"ldap://[2001:db8::7]/c=GB?objectClass?one",
+	"mailto:John.Doe@example.com",
+	"news:comp.infosystems.www.servers.unix",
+	"tel:+1-816-555-1212",
+	"telnet://192.0.2.16:80/",
+	"urn:oasis:names:specification:docbook:dtd:xml:4.1.2",
+	// RFC 3986, Section 3
+	"foo://example.com:8042/over/there?name=ferret#nose",
+	"urn:example:animal:ferret:nose",
+	// RFC 3986, Section 3.2.2
+	"http://[::1]/",
+	"http://[v7.fe80::a+en1]/",
+	// RFC 3986, Section 5.4: base, references, and results
+	"http://a/b/c/d;p?q",
+	"g:h", "g", "./g", "g/", "/g", "//g", "?y", "g?y", "#s", "g#s", "g?y#s", ";x", "g;x", "g;x?y#s", "", ".", "./", "..", "../", "../g", "../..", "../../", "../../g",
+	"../../../g", "../../../../g", "/./g", "/../g", "g.", ".g", "g..", "..g", "./../g", "./g/.", "g/./h", "g/../h", "g;x=1/./y", "g;x=1/../y", "g?y/./x", "g?y/../x", "g#s/./x", "g#s/../x", "http:g",
+	"http://a/b/c/g", "http://a/b/c/g/", "http://a/g", "http://g", "http://a/b/c/d;p?y", "http://a/b/c/g?y", "http://a/b/c/d;p?q#s", "http://a/b/c/g#s",
+	"http://a/b/c/g?y#s", "http://a/b/c/;x", "http://a/b/c/g;x", "http://a/b/c/g;x?y#s", "http://a/b/c/", "http://a/b/", "http://a/b/g", "http://a/",
+	"http://a/b/c/g.", "http://a/b/c/.g", "http://a/b/c/g..", "http://a/b/c/..g", "http://a/b/g", "http://a/b/c/g/h", "http://a/b/c/h",
+	"http://a/b/c/g;x=1/y", "http://a/b/c/y", "http://a/b/c/g?y/./x", "http://a/b/c/g?y/../x", "http://a/b/c/g#s/./x", "http://a/b/c/g#s/../x",
+	// RFC 3986, Section 6.2.2
+	"example://a/b/c/%7Bfoo%7D",
+	"eXAMPLE://a/./b/../b/%63/%7bfoo%7d",
+	"HTTP://www.EXAMPLE.com/",
+	"http://www.example.com/",
+	// RFC 3986, Section 6.2.3
+	"http://example.com",
+	"http://example.com/",
+	"http://example.com:/",
+	"http://example.com:80/",
+	// RFC 3987, Section 3.1 and 3.2
+	"http://résumé.example.org",
+	"http://r%C3%A9sum%C3%A9.example.org",
+	"http://www.example.org/red%09ros%C3%A9#red",
+	"http://www.example.org/r%E9sum%E9.html",
+	"http://www.example.org/D%C3%BCrst",
+	"http://www.example.org/Dürst",
+	"http://www.example.org/D%FCrst",
+	"http://xn--99zt52a.example.org/%e2%80%ae",
+	"http://www.example.org/%E2%80%AE",
+	// RFC 3987, Section 5.3.2
+	"http://www.example.org/résumé.html",
+	"http://www.example.org/r%C3%A9sum%C3%A9.html",
+	"http://www.example.org/r%c3%a9sum%c3%a9.html",
+	"example://a/b/c/%7Bfoo%7D/ros%C3%A9",
+	"eXAMPLE://a/./b/../b/%63/%7bfoo%7d/ros%C3%A9",
+	"http://www.example.org/%7euser",
+	"http://www.example.org/~user",
+}
+
+// TestRFCSamplesProperties checks the invariants of parsing and normalization for all samples of the RFCs.
+//
+// Samples with percent-encoded octets that are not valid UTF-8 must fail to parse, as IRIs are based on UTF-8.
+func TestRFCSamplesProperties(t *testing.T) {
+	invalidUTF8 := map[string]bool{
+		"http://www.example.org/r%E9sum%E9.html": true,
+		"http://www.example.org/D%FCrst":         true,
+	}
+	t.Parallel()
+	for _, sample := range rfcSamples {
+		sample := sample
+		t.Run(sample, func(t *testing.T) {
+			t.Parallel()
+			parsed, err := iri.Parse(sample)
+			if invalidUTF8[sample] {
+				if err == nil {
+					t.Errorf("Parse() did not return an error for invalid UTF-8")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if parsed.String() != sample {
+				t.Errorf("Parse().String() round-trip failed: got %q", parsed)
+			}
+			normalized, err := parsed.Normalize()
+			if err != nil {
+				t.Fatalf("Normalize() returned error: %v", err)
+			}
+			renormalized, err := normalized.Normalize()
+			if err != nil {
+				t.Fatalf("Normalize() of normalized IRI returned error: %v", err)
+			}
+			if renormalized != normalized {
+				t.Errorf("Normalize() is not idempotent: got %q, then %q", normalized, renormalized)
+			}
+			reparsed, err := iri.Parse(normalized.String())
+			if err != nil {
+				t.Fatalf("Parse() of normalized IRI %q returned error: %v", normalized, err)
+			}
+			if reparsed.String() != normalized.String() {
+				t.Errorf("Parse().String() round-trip of normalized IRI failed: got %q, want %q", reparsed, normalized)
+			}
+		})
+	}
+}
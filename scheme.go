@@ -0,0 +1,102 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultPorts maps schemes to the port that is used if an authority has none.
+var defaultPorts = map[string]string{
+	"ftp":   "21",
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+}
+
+// specialSchemes are the special schemes as per the WHATWG URL standard.
+// See https://url.spec.whatwg.org/#special-scheme.
+var specialSchemes = map[string]struct{}{
+	"ftp":   {},
+	"file":  {},
+	"http":  {},
+	"https": {},
+	"ws":    {},
+	"wss":   {},
+}
+
+// IsSpecialScheme returns true if the scheme of the IRI is one of the special schemes of the
+// WHATWG URL standard: "ftp", "file", "http", "https", "ws", or "wss". The scheme is compared case-insensitively.
+func (iri IRI) IsSpecialScheme() bool {
+	_, special := specialSchemes[strings.ToLower(iri.Scheme)]
+	return special
+}
+
+// DefaultPort returns the default port of the scheme of the IRI, and false if the scheme has none.
+// The scheme is compared case-insensitively.
+func (iri IRI) DefaultPort() (string, bool) {
+	port, known := defaultPorts[strings.ToLower(iri.Scheme)]
+	return port, known
+}
+
+// StripDefaultPort returns an IRI without the port of the authority, if that port is empty or
+// the default port of the scheme. This is a scheme-based normalization.
+//
+// See https://www.rfc-editor.org/rfc/rfc3986#section-6.2.3.
+func (iri IRI) StripDefaultPort() IRI {
+	defaultPort, _ := iri.DefaultPort()
+	return iri.stripPort(defaultPort)
+}
+
+// stripPort removes the port of the authority if it is empty or equal to the given default port.
+// If the default port is empty, only an empty port is removed.
+func (iri IRI) stripPort(defaultPort string) IRI {
+	parts := splitAuthority(iri.Authority)
+	if !parts.hasPort {
+		return iri
+	}
+	if (parts.port != "") && ((defaultPort == "") || (strings.TrimLeft(parts.port, "0") != defaultPort)) {
+		return iri
+	}
+	stripped := iri
+	parts.port, parts.hasPort = "", false
+	stripped.Authority = parts.String()
+	return stripped
+}
+
+// Origin returns the serialized origin of the IRI, consisting of scheme, host, and port.
+// The default port of the scheme is omitted, and scheme and host are lowercase.
+//
+// Only IRIs with a host and a scheme that has a default port have an origin;
+// For all others, this method returns false.
+// See https://www.rfc-editor.org/rfc/rfc6454#section-6.1.
+func (iri IRI) Origin() (string, bool) {
+	if _, known := iri.DefaultPort(); !known || (iri.Host() == "") {
+		return "", false
+	}
+	parts := splitAuthority(iri.StripDefaultPort().Authority)
+	parts.userInfo, parts.hasUserInfo = "", false
+	parts.host = lowercaseHost(parts.host)
+	return strings.ToLower(iri.Scheme) + "://" + parts.String(), true
+}
+
+// SchemeSpecificPart returns everything after the colon of the scheme, such as "//host/path?q#f"
+// for "http://host/path?q#f", or "user@example.com?subject=Hi" for "mailto:user@example.com?subject=Hi".
+// For an IRI without scheme, this is its complete string.
+//
+// The returned value includes the fragment and is kept percent-encoded.
+func (iri IRI) SchemeSpecificPart() string {
+	withoutScheme := iri
+	withoutScheme.Scheme = ""
+	return withoutScheme.String()
+}
+
+// ParseSchemeSpecificPart parses the combination of scheme and scheme-specific part, as returned
+// by IRI.SchemeSpecificPart, into an IRI. It returns an error if the scheme is empty or invalid,
+// or if the result is not a valid IRI.
+func ParseSchemeSpecificPart(scheme, ssp string, opts ...ParseOption) (IRI, error) {
+	if !schemeRE.MatchString(scheme) {
+		return IRI{}, fmt.Errorf("invalid scheme %q does not match regexp %s", scheme, schemeRE)
+	}
+	return Parse(scheme+":"+ssp, opts...)
+}
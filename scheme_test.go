@@ -0,0 +1,171 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestIsSpecialScheme(t *testing.T) {
+	tt := []struct {
+		in   string
+		want bool
+	}{
+		{in: "http://a", want: true},
+		{in: "HTTPS://a", want: true},
+		{in: "ws://a", want: true},
+		{in: "wss://a", want: true},
+		{in: "ftp://a", want: true},
+		{in: "file:///a", want: true},
+		{in: "mailto:a@b", want: false},
+		{in: "urn:x:y", want: false},
+		{in: "//a", want: false},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.IsSpecialScheme(); got != tc.want {
+				t.Errorf("IsSpecialScheme() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStripDefaultPort(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "http://a:80/p", want: "http://a/p"},
+		{in: "http://a:080/p", want: "http://a/p"},
+		{in: "http://a:/p", want: "http://a/p"},
+		{in: "http://a:8080/p", want: "http://a:8080/p"},
+		{in: "https://u@a:443", want: "https://u@a"},
+		{in: "ws://a:80", want: "ws://a"},
+		{in: "wss://[::1]:443", want: "wss://[::1]"},
+		{in: "wss://a:80", want: "wss://a:80"},
+		{in: "ftp://a:21", want: "ftp://a"},
+		{in: "foo://a:80", want: "foo://a:80"},
+		{in: "foo://a:", want: "foo://a"},
+		{in: "http://a", want: "http://a"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.StripDefaultPort(); got.String() != tc.want {
+				t.Errorf("StripDefaultPort() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOrigin(t *testing.T) {
+	tt := []struct {
+		in     string
+		want   string
+		wantOk bool
+	}{
+		{in: "ws://Example.com:80/chat", want: "ws://example.com", wantOk: true},
+		{in: "wss://example.com:443/chat?x", want: "wss://example.com", wantOk: true},
+		{in: "wss://example.com:8443/chat", want: "wss://example.com:8443", wantOk: true},
+		{in: "HTTPS://user:pw@example.com/a#b", want: "https://example.com", wantOk: true},
+		{in: "http://[::1]:8080", want: "http://[::1]:8080", wantOk: true},
+		{in: "file:///etc/hosts", wantOk: false},
+		{in: "mailto:user@example.com", wantOk: false},
+		{in: "http:///path", wantOk: false},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, ok := value.Origin()
+			if (got != tc.want) || (ok != tc.wantOk) {
+				t.Errorf("Origin() = (%q, %v), want (%q, %v)", got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestSchemeSpecificPart(t *testing.T) {
+	tt := []struct {
+		in   string
+		want string
+	}{
+		{in: "mailto:user@x?subject=Hi", want: "user@x?subject=Hi"},
+		{in: "http://h/p", want: "//h/p"},
+		{in: "http://user@h:80/p?q#f", want: "//user@h:80/p?q#f"},
+		{in: "urn:isbn:0451450523", want: "isbn:0451450523"},
+		{in: "file:///etc/hosts", want: "///etc/hosts"},
+		{in: "about:", want: ""},
+		{in: "foo:?#", want: "?#"},
+		{in: "../g", want: "../g"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := value.SchemeSpecificPart(); got != tc.want {
+				t.Errorf("SchemeSpecificPart() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseSchemeSpecificPart(t *testing.T) {
+	tt := []struct {
+		scheme  string
+		ssp     string
+		want    string
+		wantErr bool
+	}{
+		{scheme: "mailto", ssp: "user@x?subject=Hi", want: "mailto:user@x?subject=Hi"},
+		{scheme: "mailto", ssp: "a@example.com,b@example.com", want: "mailto:a@example.com,b@example.com"},
+		{scheme: "http", ssp: "//h/p?q#f", want: "http://h/p?q#f"},
+		{scheme: "urn", ssp: "isbn:0451450523", want: "urn:isbn:0451450523"},
+		{scheme: "about", ssp: "", want: "about:"},
+		{scheme: "mailto", ssp: "user@x?subject=Hi there", wantErr: true},
+		{scheme: "mailto", ssp: "%FF", wantErr: true},
+		{scheme: "", ssp: "user@x", wantErr: true},
+		{scheme: "a/b", ssp: "c", wantErr: true},
+		{scheme: "1a", ssp: "c", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.scheme+":"+tc.ssp, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.ParseSchemeSpecificPart(tc.scheme, tc.ssp)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("ParseSchemeSpecificPart() = %q, want %q", got, tc.want)
+			}
+			if !tc.wantErr && (got.SchemeSpecificPart() != tc.ssp) {
+				t.Errorf("SchemeSpecificPart() = %q, want %q", got.SchemeSpecificPart(), tc.ssp)
+			}
+		})
+	}
+}
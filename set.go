@@ -0,0 +1,52 @@
+package iri
+
+import "sort"
+
+// Set is a collection of IRIs, in which IRIs that are equal under EqualNormalized are the same member.
+//
+// The zero value is an empty set, ready to use. A Set is not safe for concurrent use.
+type Set struct {
+	members map[string]IRI
+}
+
+// Add adds the IRI to the set. If an equal IRI is already a member, the set remains unchanged.
+func (set *Set) Add(iri IRI) {
+	key := iri.CanonicalString()
+	if _, exists := set.members[key]; exists {
+		return
+	}
+	if set.members == nil {
+		set.members = make(map[string]IRI)
+	}
+	set.members[key] = iri
+}
+
+// Contains returns true if an IRI equal to the given one is a member of the set.
+func (set *Set) Contains(iri IRI) bool {
+	_, exists := set.members[iri.CanonicalString()]
+	return exists
+}
+
+// Remove removes the member that is equal to the given IRI, if any.
+func (set *Set) Remove(iri IRI) {
+	delete(set.members, iri.CanonicalString())
+}
+
+// Len returns the number of members.
+func (set *Set) Len() int {
+	return len(set.members)
+}
+
+// Slice returns the members of the set, as they were added, ordered by their canonical string.
+func (set *Set) Slice() []IRI {
+	keys := make([]string, 0, len(set.members))
+	for key := range set.members {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]IRI, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, set.members[key])
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestSetDeduplicatesEqualIRIs(t *testing.T) {
+	t.Parallel()
+	var set iri.Set
+	for _, s := range []string{"http://x/µ", "http://x/%C2%B5", "HTTP://X/%c2%b5", "http://x/a/../µ"} {
+		value, err := iri.Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", s, err)
+		}
+		set.Add(value)
+	}
+	if set.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1", set.Len())
+	}
+	if got := set.Slice()[0].String(); got != "http://x/µ" {
+		t.Errorf("Slice()[0] = %q, want first added IRI", got)
+	}
+	if !set.Contains(iri.IRI{Scheme: "http", Authority: "x", Path: "/%C2%B5"}) {
+		t.Errorf("Contains() = false for encoded variant")
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	t.Parallel()
+	var set iri.Set
+	a := iri.IRI{Scheme: "http", Authority: "x", Path: "/a"}
+	b := iri.IRI{Scheme: "http", Authority: "x", Path: "/b"}
+	if set.Contains(a) || (set.Len() != 0) || (len(set.Slice()) != 0) {
+		t.Fatalf("zero set is not empty")
+	}
+	set.Remove(a)
+	set.Add(b)
+	set.Add(a)
+	if got := set.Slice(); (len(got) != 2) || (got[0] != a) || (got[1] != b) {
+		t.Errorf("Slice() = %v, want [%v %v]", got, a, b)
+	}
+	set.Remove(iri.IRI{Scheme: "HTTP", Authority: "X", Path: "/%61"})
+	if set.Contains(a) || !set.Contains(b) || (set.Len() != 1) {
+		t.Errorf("Remove() did not remove equal IRI: %v", set.Slice())
+	}
+}
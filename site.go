@@ -0,0 +1,29 @@
+package iri
+
+import (
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// Site returns the scheme together with the registrable domain of the host, such as
+// "https://example.co.uk" for "https://a.b.example.co.uk/path". The registrable domain
+// is the effective top-level domain plus one label (eTLD+1), as determined by the public
+// suffix list. Scheme and host are lowercase, and the host is in its ASCII form.
+//
+// This method returns false for IRIs without scheme, with a host that is not a registered
+// name, such as an IP address, or with a host that is itself a public suffix, such as "co.uk".
+func (iri IRI) Site() (string, bool) {
+	if !iri.hasScheme() || (iri.HostType() != HostTypeRegName) {
+		return "", false
+	}
+	host, err := hostToASCII(iri.Host())
+	if err != nil {
+		return "", false
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(strings.TrimSuffix(strings.ToLower(host), "."))
+	if err != nil {
+		return "", false
+	}
+	return strings.ToLower(iri.Scheme) + "://" + domain, true
+}
@@ -0,0 +1,42 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestSite(t *testing.T) {
+	tt := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{in: "https://a.b.example.co.uk", want: "https://example.co.uk", wantOK: true},
+		{in: "https://example.co.uk/path?q#f", want: "https://example.co.uk", wantOK: true},
+		{in: "HTTP://User@WWW.Example.COM:8080/", want: "http://example.com", wantOK: true},
+		{in: "https://www.example.com./", want: "https://example.com", wantOK: true},
+		{in: "https://www.bücher.de/", want: "https://xn--bcher-kva.de", wantOK: true},
+		{in: "https://co.uk/"},
+		{in: "https://192.0.2.1/"},
+		{in: "https://[2001:db8::1]/"},
+		{in: "mailto:user@example.com"},
+		{in: "file:///etc/hosts"},
+		{in: "//www.example.com/"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, ok := value.Site()
+			if (got != tc.want) || (ok != tc.wantOK) {
+				t.Errorf("Site() = %q, %v, want %q, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
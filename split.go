@@ -0,0 +1,28 @@
+package iri
+
+// SplitFragment splits a raw IRI string at the first hash ('#').
+//
+// The split follows the same rules as Parse, without validating any component.
+// If there is no fragment, beforeHash is the full string, and hasFragment is false.
+func SplitFragment(s string) (beforeHash, fragment string, hasFragment bool) {
+	loc := uriRE.FindStringSubmatchIndex(s)
+	hashStart := loc[2*uriREFragmentWithHashGroup]
+	if hashStart < 0 {
+		return s, "", false
+	}
+	return s[:hashStart], s[loc[2*uriREFragmentGroup]:loc[2*uriREFragmentGroup+1]], true
+}
+
+// SplitQuery splits a raw IRI string at the first question mark ('?') that comes before any hash ('#').
+//
+// The split follows the same rules as Parse, without validating any component.
+// The query ends before the fragment, and the fragment is not part of either result.
+// If there is no query, beforeQuestion is the string before any fragment, and hasQuery is false.
+func SplitQuery(s string) (beforeQuestion, query string, hasQuery bool) {
+	loc := uriRE.FindStringSubmatchIndex(s)
+	questionStart := loc[2*uriREQueryWithMarkGroup]
+	if questionStart < 0 {
+		return s[:loc[2*uriREPathGroup+1]], "", false
+	}
+	return s[:questionStart], s[loc[2*uriREQueryGroup]:loc[2*uriREQueryGroup+1]], true
+}
@@ -0,0 +1,64 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestSplitFragment(t *testing.T) {
+	tt := []struct {
+		in              string
+		wantBeforeHash  string
+		wantFragment    string
+		wantHasFragment bool
+	}{
+		{in: "", wantBeforeHash: ""},
+		{in: "https://example.com/path?q", wantBeforeHash: "https://example.com/path?q"},
+		{in: "https://example.com/path?q#frag", wantBeforeHash: "https://example.com/path?q", wantFragment: "frag", wantHasFragment: true},
+		{in: "https://example.com#", wantBeforeHash: "https://example.com", wantHasFragment: true},
+		{in: "a#b?c", wantBeforeHash: "a", wantFragment: "b?c", wantHasFragment: true},
+		{in: "a#b#c", wantBeforeHash: "a", wantFragment: "b#c", wantHasFragment: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			beforeHash, fragment, hasFragment := iri.SplitFragment(tc.in)
+			if (beforeHash != tc.wantBeforeHash) || (fragment != tc.wantFragment) || (hasFragment != tc.wantHasFragment) {
+				t.Errorf("SplitFragment(%q) = (%q, %q, %v), want (%q, %q, %v)", tc.in,
+					beforeHash, fragment, hasFragment, tc.wantBeforeHash, tc.wantFragment, tc.wantHasFragment)
+			}
+		})
+	}
+}
+
+func TestSplitQuery(t *testing.T) {
+	tt := []struct {
+		in                 string
+		wantBeforeQuestion string
+		wantQuery          string
+		wantHasQuery       bool
+	}{
+		{in: "", wantBeforeQuestion: ""},
+		{in: "https://example.com/path", wantBeforeQuestion: "https://example.com/path"},
+		{in: "https://example.com/path?q=1", wantBeforeQuestion: "https://example.com/path", wantQuery: "q=1", wantHasQuery: true},
+		{in: "https://example.com/path?q=1#frag", wantBeforeQuestion: "https://example.com/path", wantQuery: "q=1", wantHasQuery: true},
+		{in: "https://example.com?", wantBeforeQuestion: "https://example.com", wantHasQuery: true},
+		{in: "a?b?c", wantBeforeQuestion: "a", wantQuery: "b?c", wantHasQuery: true},
+		{in: "a#b?c", wantBeforeQuestion: "a"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			beforeQuestion, query, hasQuery := iri.SplitQuery(tc.in)
+			if (beforeQuestion != tc.wantBeforeQuestion) || (query != tc.wantQuery) || (hasQuery != tc.wantHasQuery) {
+				t.Errorf("SplitQuery(%q) = (%q, %q, %v), want (%q, %q, %v)", tc.in,
+					beforeQuestion, query, hasQuery, tc.wantBeforeQuestion, tc.wantQuery, tc.wantHasQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package iri
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements the sql.Scanner interface.
+//
+// The source must be a string or a byte slice, which is parsed with Parse.
+// An SQL NULL value results in the empty IRI, the same as an empty string.
+func (iri *IRI) Scan(src any) error {
+	var s string
+	switch value := src.(type) {
+	case nil:
+		s = ""
+	case string:
+		s = value
+	case []byte:
+		s = string(value)
+	default:
+		return fmt.Errorf("cannot scan IRI from source type %T", src)
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*iri = parsed
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+//
+// The value is the string of the IRI. The empty IRI results in an empty string, never in an SQL NULL value.
+func (iri IRI) Value() (driver.Value, error) {
+	return iri.String(), nil
+}
@@ -0,0 +1,73 @@
+package iri_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+var (
+	_ sql.Scanner   = (*iri.IRI)(nil)
+	_ driver.Valuer = iri.IRI{}
+)
+
+func TestScan(t *testing.T) {
+	tt := []struct {
+		name    string
+		src     any
+		want    string
+		wantErr bool
+	}{
+		{name: "string", src: "https://example.com/µ", want: "https://example.com/µ"},
+		{name: "bytes", src: []byte("https://example.com/µ"), want: "https://example.com/µ"},
+		{name: "empty string", src: "", want: ""},
+		{name: "null", src: nil, want: ""},
+		{name: "invalid IRI", src: "https://example.com/ ", wantErr: true},
+		{name: "non-string source", src: int64(1), wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got iri.IRI
+			err := got.Scan(tc.src)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got.String() != tc.want {
+				t.Errorf("Scan(%v) = %q, want %q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	tt := []struct {
+		in   iri.IRI
+		want driver.Value
+	}{
+		{in: iri.IRI{}, want: ""},
+		{in: iri.IRI{ForceAuthority: true, ForceQuery: true, ForceFragment: true}, want: "//?#"},
+		{in: iri.IRI{Scheme: "https", Authority: "example.com", Path: "/µ"}, want: "https://example.com/µ"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in.String(), func(t *testing.T) {
+			t.Parallel()
+			got, err := tc.in.Value()
+			if err != nil {
+				t.Fatalf("Value() returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Value() = %v, want %v", got, tc.want)
+			}
+			if !driver.IsValue(got) {
+				t.Errorf("Value() returned unsupported type %T", got)
+			}
+		})
+	}
+}
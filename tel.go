@@ -0,0 +1,48 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TelParts returns the telephone number and the parameters of a "tel" IRI, as per RFC 3966.
+//
+// The number is the path up to the first semicolon (';'). The parameters follow, each
+// in the form ";name=value", or ";name" for a parameter without value. Parameter names are
+// case-insensitive and returned in lowercase. Number and values are percent-decoded.
+// Should a parameter name occur several times, the last value is returned.
+//
+// It returns an error if the scheme is not "tel", the number is empty, or if any part contains invalid percent-encoding.
+func (iri IRI) TelParts() (number string, params map[string]string, err error) {
+	if !strings.EqualFold(iri.Scheme, "tel") {
+		return "", nil, fmt.Errorf("%q is not a tel IRI", iri)
+	}
+	rawNumber, rawParams, _ := strings.Cut(iri.Path, ";")
+	if rawNumber == "" {
+		return "", nil, fmt.Errorf("%q is not a valid tel IRI: number is missing", iri)
+	}
+	number, err = Unescape(rawNumber)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid number %q: %w", rawNumber, err)
+	}
+	params = map[string]string{}
+	if rawParams == "" {
+		return number, params, nil
+	}
+	for _, param := range strings.Split(rawParams, ";") {
+		if param == "" {
+			continue
+		}
+		rawName, rawValue, _ := strings.Cut(param, "=")
+		name, err := Unescape(rawName)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid parameter name %q: %w", rawName, err)
+		}
+		value, err := Unescape(rawValue)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid parameter value %q: %w", rawValue, err)
+		}
+		params[strings.ToLower(name)] = value
+	}
+	return number, params, nil
+}
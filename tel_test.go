@@ -0,0 +1,67 @@
+package iri_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestTelParts(t *testing.T) {
+	tt := []struct {
+		in         string
+		wantNumber string
+		wantParams map[string]string
+		wantErr    bool
+	}{
+		{
+			in:         "tel:+1-816-555-1212",
+			wantNumber: "+1-816-555-1212",
+			wantParams: map[string]string{},
+		},
+		{
+			in:         "tel:7042;phone-context=example.com",
+			wantNumber: "7042",
+			wantParams: map[string]string{"phone-context": "example.com"},
+		},
+		{
+			in:         "TEL:863-1234;Phone-Context=+1-914-555;ext=42;isub=%41b",
+			wantNumber: "863-1234",
+			wantParams: map[string]string{"phone-context": "+1-914-555", "ext": "42", "isub": "Ab"},
+		},
+		{
+			in:         "tel:+1-201-555-0123;;enum",
+			wantNumber: "+1-201-555-0123",
+			wantParams: map[string]string{"enum": ""},
+		},
+		{
+			in:      "tel:;phone-context=example.com",
+			wantErr: true,
+		},
+		{
+			in:      "mailto:John.Doe@example.com",
+			wantErr: true,
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			number, params, err := value.TelParts()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if number != tc.wantNumber {
+				t.Errorf("TelParts() number got %q, want %q", number, tc.wantNumber)
+			}
+			if !reflect.DeepEqual(params, tc.wantParams) {
+				t.Errorf("TelParts() params got %q, want %q", params, tc.wantParams)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package iri
+
+import (
+	"sort"
+	"strings"
+)
+
+// Template is a URI template as per RFC 6570, such as "https://example.com/users/{id}".
+// Templates created by Templatize only use simple string expansion of Level 1.
+//
+// See https://www.rfc-editor.org/rfc/rfc6570.
+type Template string
+
+// String returns the template as string.
+func (template Template) String() string {
+	return string(template)
+}
+
+// Templatize returns a template of the IRI in which the values of the given variables are
+// replaced by their expressions, such as "{id}". This is the reverse of a template expansion,
+// for example to document the routes of an API from sample requests.
+//
+// Only complete path segments and complete values of query parameters are replaced; A value
+// that occurs as part of a segment, such as "1" in "v1", is kept. Segments and query values
+// are compared after percent-decoding, and empty values are never replaced.
+//
+// A value that occurs at several places is replaced at each of them, even if only one of them
+// was a variable in the original template. If several variables have the same value, the
+// variable with the name that sorts first is used. Use distinctive sample values to avoid
+// such ambiguity.
+func Templatize(iri IRI, vars map[string]string) Template {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	expressions := make(map[string]string, len(vars))
+	for _, name := range names {
+		if value := vars[name]; (value != "") && (expressions[value] == "") {
+			expressions[value] = "{" + name + "}"
+		}
+	}
+
+	result := iri
+	segments := strings.Split(iri.Path, "/")
+	for i, segment := range segments {
+		decoded, err := Unescape(segment)
+		if err != nil {
+			decoded = segment
+		}
+		if expression, found := expressions[decoded]; found {
+			segments[i] = expression
+		}
+	}
+	result.Path = strings.Join(segments, "/")
+	if iri.Query != "" {
+		pairs := strings.Split(iri.Query, "&")
+		for i, pair := range pairs {
+			key, value, hasValue := strings.Cut(pair, "=")
+			if expression, found := expressions[queryUnescapeOrRaw(value)]; hasValue && found {
+				pairs[i] = key + "=" + expression
+			}
+		}
+		result.Query = strings.Join(pairs, "&")
+	}
+	return Template(result.String())
+}
@@ -0,0 +1,85 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestTemplatize(t *testing.T) {
+	tt := []struct {
+		name string
+		in   string
+		vars map[string]string
+		want iri.Template
+	}{
+		{
+			name: "ID segment",
+			in:   "https://example.com/users/1234/profile",
+			vars: map[string]string{"id": "1234"},
+			want: "https://example.com/users/{id}/profile",
+		},
+		{
+			name: "several variables",
+			in:   "https://example.com/users/1234/posts/abc",
+			vars: map[string]string{"user": "1234", "post": "abc"},
+			want: "https://example.com/users/{user}/posts/{post}",
+		},
+		{
+			name: "partial occurrence is kept",
+			in:   "https://example.com/v1/items/1",
+			vars: map[string]string{"id": "1"},
+			want: "https://example.com/v1/items/{id}",
+		},
+		{
+			name: "value at several places",
+			in:   "https://example.com/a/7/b/7",
+			vars: map[string]string{"id": "7"},
+			want: "https://example.com/a/{id}/b/{id}",
+		},
+		{
+			name: "same value of several variables",
+			in:   "https://example.com/a/7/b/7",
+			vars: map[string]string{"b": "7", "a": "7"},
+			want: "https://example.com/a/{a}/b/{a}",
+		},
+		{
+			name: "percent-encoded segment",
+			in:   "https://example.com/files/my%20file",
+			vars: map[string]string{"name": "my file"},
+			want: "https://example.com/files/{name}",
+		},
+		{
+			name: "query values",
+			in:   "https://example.com/search?q=go+lang&page=2&2",
+			vars: map[string]string{"query": "go lang", "page": "2"},
+			want: "https://example.com/search?q={query}&page={page}&2",
+		},
+		{
+			name: "empty value is ignored",
+			in:   "https://example.com/a//b",
+			vars: map[string]string{"empty": ""},
+			want: "https://example.com/a//b",
+		},
+		{
+			name: "no variables",
+			in:   "https://example.com/a#1",
+			vars: nil,
+			want: "https://example.com/a#1",
+		},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			in, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			if got := iri.Templatize(in, tc.vars); got != tc.want {
+				t.Errorf("Templatize(%q, %v) = %q, want %q", tc.in, tc.vars, got, tc.want)
+			}
+		})
+	}
+}
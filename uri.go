@@ -0,0 +1,21 @@
+package iri
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// ParseURI parses a string like Parse, yet only accepts URIs as per RFC 3986.
+//
+// Any non-ASCII character is rejected, including those of the ucschar and iprivate
+// productions of RFC 3987. Such characters must be percent-encoded in a URI.
+// Without them, the grammar of RFC 3987 is equal to that of RFC 3986.
+func ParseURI(s string, opts ...ParseOption) (IRI, error) {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			r, _ := utf8.DecodeRuneInString(s[i:])
+			return IRI{}, fmt.Errorf("%q is not a valid URI: non-ASCII character %q at offset %d", s, r, i)
+		}
+	}
+	return Parse(s, opts...)
+}
@@ -0,0 +1,50 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestParseURI(t *testing.T) {
+	tt := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: "https://example.org/a?b#c"},
+		{in: "https://%C3%A9.example.org/%C2%B5"},
+		{in: "urn:isbn:0451450523"},
+		{in: ""},
+		{in: "https://é.example.org", wantErr: true},
+		{in: "https://example.org/µ", wantErr: true},
+		{in: "https://example.org/?q=\uE000", wantErr: true},
+		{in: "https://example.org/#\U0001F600", wantErr: true},
+		{in: "https://example.org/%zz", wantErr: true},
+		{in: "https://exa mple.org", wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := iri.ParseURI(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if (err == nil) && (got.String() != tc.in) {
+				t.Errorf("ParseURI(%q).String() = %q", tc.in, got)
+			}
+		})
+	}
+}
+
+func TestParseURIRejectsWhatParseAccepts(t *testing.T) {
+	t.Parallel()
+	const in = "https://é.example.org"
+	if _, err := iri.Parse(in); err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if _, err := iri.ParseURI(in); err == nil {
+		t.Errorf("ParseURI(%q) returned no error", in)
+	}
+}
@@ -0,0 +1,58 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// urnNIDRE matches a namespace identifier as per RFC 8141, section 2:
+//
+//	NID = (alphanum) 0*30(ldh) (alphanum)
+var urnNIDRE = mustCompileNamed("urnNIDRE", `^[a-zA-Z0-9][a-zA-Z0-9\-]{0,30}[a-zA-Z0-9]$`)
+
+// URN returns the namespace identifier (NID) and the namespace specific string (NSS)
+// of an IRI with the "urn" scheme, as per RFC 8141.
+//
+// For "urn:oasis:names:specification:docbook:dtd:xml:4.1.2", the NID is "oasis" and the NSS is
+// "names:specification:docbook:dtd:xml:4.1.2". The NSS is kept percent-encoded.
+// The returned bool is false if the scheme is not "urn", compared case-insensitively.
+// An error is returned if the scheme is "urn", yet the NID or the NSS is malformed.
+//
+// See https://www.rfc-editor.org/rfc/rfc8141.html.
+func (iri IRI) URN() (nid string, nss string, ok bool, err error) {
+	if !strings.EqualFold(iri.Scheme, "urn") {
+		return "", "", false, nil
+	}
+	if iri.hasAuthority() {
+		return "", "", true, fmt.Errorf("%q is not a valid URN: authority is not allowed", iri)
+	}
+	nid, nss, found := strings.Cut(iri.Path, ":")
+	if !found {
+		return "", "", true, fmt.Errorf("%q is not a valid URN: colon after namespace identifier is missing", iri)
+	}
+	if !urnNIDRE.MatchString(nid) {
+		return "", "", true, fmt.Errorf("%q is not a valid URN: invalid namespace identifier %q does not match regexp %s", iri, nid, urnNIDRE)
+	}
+	if (nss == "") || strings.HasPrefix(nss, "/") {
+		return "", "", true, fmt.Errorf("%q is not a valid URN: namespace specific string %q must not be empty or begin with a slash", iri, nss)
+	}
+	return nid, nss, true, nil
+}
+
+var uuidRE = mustCompileNamed("uuidRE", `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// UUID returns the UUID of an IRI in the form "urn:uuid:<uuid>", as per RFC 4122, section 3.
+//
+// The UUID is returned as is, without case normalization. The returned bool is false if
+// the IRI is not a valid URN with the namespace identifier "uuid", or if the UUID does not
+// have the hexadecimal 8-4-4-4-12 layout.
+func (iri IRI) UUID() (string, bool) {
+	nid, nss, ok, err := iri.URN()
+	if !ok || (err != nil) || !strings.EqualFold(nid, "uuid") || iri.hasQuery() || iri.hasFragment() {
+		return "", false
+	}
+	if !uuidRE.MatchString(nss) {
+		return "", false
+	}
+	return nss, true
+}
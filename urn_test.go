@@ -0,0 +1,87 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestURN(t *testing.T) {
+	tt := []struct {
+		in      string
+		wantNID string
+		wantNSS string
+		wantOk  bool
+		wantErr bool
+	}{
+		{in: "urn:oasis:names:specification:docbook:dtd:xml:4.1.2", wantNID: "oasis", wantNSS: "names:specification:docbook:dtd:xml:4.1.2", wantOk: true},
+		{in: "urn:uuid:6c689097-8097-4421-9def-05e835f2dbb8", wantNID: "uuid", wantNSS: "6c689097-8097-4421-9def-05e835f2dbb8", wantOk: true},
+		{in: "URN:ISBN:0451450523", wantNID: "ISBN", wantNSS: "0451450523", wantOk: true},
+		{in: "urn:example:a/b%20c?+r?=q#f", wantNID: "example", wantNSS: "a/b%20c", wantOk: true},
+		{in: "urn:x-1:y", wantNID: "x-1", wantNSS: "y", wantOk: true},
+		{in: "https://example.com/urn:a:b"},
+		{in: "mailto:urn:a:b"},
+		{in: "urn:uuid:", wantOk: true, wantErr: true},
+		{in: "urn:uuid", wantOk: true, wantErr: true},
+		{in: "urn:", wantOk: true, wantErr: true},
+		{in: "urn:a:b", wantOk: true, wantErr: true},
+		{in: "urn:-a:b", wantOk: true, wantErr: true},
+		{in: "urn:a-:b", wantOk: true, wantErr: true},
+		{in: "urn:a_b:c", wantOk: true, wantErr: true},
+		{in: "urn:abcdefghijabcdefghijabcdefghijabc:x", wantOk: true, wantErr: true},
+		{in: "urn:ab:/x", wantOk: true, wantErr: true},
+		{in: "urn://host/x", wantOk: true, wantErr: true},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			nid, nss, ok, err := value.URN()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if (nid != tc.wantNID) || (nss != tc.wantNSS) || (ok != tc.wantOk) {
+				t.Errorf("URN() = (%q, %q, %v), want (%q, %q, %v)", nid, nss, ok, tc.wantNID, tc.wantNSS, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestUUID(t *testing.T) {
+	tt := []struct {
+		in     string
+		want   string
+		wantOk bool
+	}{
+		{in: "urn:uuid:6c689097-8097-4421-9def-05e835f2dbb8", want: "6c689097-8097-4421-9def-05e835f2dbb8", wantOk: true},
+		{in: "URN:UUID:6C689097-8097-4421-9DEF-05E835F2DBB8", want: "6C689097-8097-4421-9DEF-05E835F2DBB8", wantOk: true},
+		{in: "urn:uuid:"},
+		{in: "urn:uuid:6c689097-8097-4421-9def-05e835f2dbb"},
+		{in: "urn:uuid:6c689097-8097-4421-9def-05e835f2dbb8a"},
+		{in: "urn:uuid:6c6890978097-4421-9def-05e835f2dbb8-"},
+		{in: "urn:uuid:6c689097-8097-4421-9def-05e835f2dbbg"},
+		{in: "urn:uuid:6c689097-8097-4421-9def-05e835f2dbb8#frag"},
+		{in: "urn:isbn:0451450523"},
+		{in: "https://example.com/6c689097-8097-4421-9def-05e835f2dbb8"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			value, err := iri.Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			got, ok := value.UUID()
+			if (got != tc.want) || (ok != tc.wantOk) {
+				t.Errorf("UUID() = (%q, %v), want (%q, %v)", got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate checks that the components of the IRI conform to RFC 3987, and that the
+// string of the IRI would be parsed into the same components.
+//
+// This is useful for manually created IRIs, as String performs no escaping.
+func (iri IRI) Validate() error {
+	if iri.hasScheme() && !schemeRE.MatchString(iri.Scheme) {
+		return fmt.Errorf("%q is not a valid IRI: invalid scheme %q does not match regexp %s", iri, iri.Scheme, schemeRE)
+	}
+	if (iri.Authority != "") && !iauthorityRE.MatchString(iri.Authority) {
+		return fmt.Errorf("%q is not a valid IRI: invalid authority %q does not match regexp %s", iri, iri.Authority, iauthorityRE)
+	}
+	if (iri.Path != "") && !ipathRE.MatchString(iri.Path) {
+		return fmt.Errorf("%q is not a valid IRI: invalid path %q does not match regexp %s", iri, iri.Path, ipathRE)
+	}
+	if (iri.Query != "") && !iqueryRE.MatchString(iri.Query) {
+		return fmt.Errorf("%q is not a valid IRI: invalid query %q does not match regexp %s", iri, iri.Query, iqueryRE)
+	}
+	if (iri.Fragment != "") && !ifragmentRE.MatchString(iri.Fragment) {
+		return fmt.Errorf("%q is not a valid IRI: invalid fragment %q does not match regexp %s", iri, iri.Fragment, ifragmentRE)
+	}
+	if err := iri.validateStructure(); err != nil {
+		return fmt.Errorf("%q is not a valid IRI: %w", iri, err)
+	}
+	if _, err := NormalizePercentEncoding(iri); err != nil {
+		return fmt.Errorf("%q is not a valid IRI: invalid percent encoding: %w", iri, err)
+	}
+	return nil
+}
+
+// SafeString returns the string of the IRI, if the IRI is valid and its string would be
+// parsed into the same IRI. Otherwise, it returns an error.
+//
+// Unlike String, this guarantees a serialization that can be parsed again.
+func (iri IRI) SafeString() (string, error) {
+	if err := iri.Validate(); err != nil {
+		return "", err
+	}
+	s := iri.String()
+	parsed, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	if parsed != iri.withMinimalForceFlags() {
+		return "", fmt.Errorf("%q is not a valid IRI: string would be parsed into different components %#v", s, parsed)
+	}
+	return s, nil
+}
+
+// withMinimalForceFlags returns the IRI with only those Force* flags set that are necessary
+// for the string of the IRI. Parse produces IRIs in this form.
+func (iri IRI) withMinimalForceFlags() IRI {
+	minimal := iri
+	minimal.ForceAuthority = iri.hasAuthority() && (iri.Authority == "")
+	minimal.ForceQuery = iri.hasQuery() && (iri.Query == "")
+	minimal.ForceFragment = iri.hasFragment() && (iri.Fragment == "")
+	return minimal
+}
+
+// validateStructure checks the constraints of the path that depend on the other components.
+// See RFC 3986, section 3.3.
+func (iri IRI) validateStructure() error {
+	if iri.hasAuthority() {
+		if (iri.Path != "") && !strings.HasPrefix(iri.Path, "/") {
+			return fmt.Errorf("path %q must be empty or begin with a slash when an authority is present", iri.Path)
+		}
+		return nil
+	}
+	if strings.HasPrefix(iri.Path, "//") {
+		return fmt.Errorf("path %q must not begin with two slashes when no authority is present", iri.Path)
+	}
+	if !iri.hasScheme() {
+		firstSegment, _, _ := strings.Cut(iri.Path, "/")
+		if strings.Contains(firstSegment, ":") {
+			return fmt.Errorf("first segment of path %q must not contain a colon when no scheme is present", iri.Path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package iri_test
+
+import (
+	"testing"
+
+	"github.com/contomap/iri"
+)
+
+func TestValidate(t *testing.T) {
+	tt := []struct {
+		name    string
+		in      iri.IRI
+		wantErr bool
+	}{
+		{name: "empty", in: iri.IRI{}},
+		{name: "complete", in: iri.IRI{Scheme: "https", Authority: "user@example.com", Path: "/µ", Query: "q=1", Fragment: "f"}},
+		{name: "forced empty components", in: iri.IRI{ForceAuthority: true, ForceQuery: true, ForceFragment: true}},
+		{name: "invalid scheme", in: iri.IRI{Scheme: "1http"}, wantErr: true},
+		{name: "invalid authority", in: iri.IRI{Authority: "a b"}, wantErr: true},
+		{name: "invalid path", in: iri.IRI{Path: "/ "}, wantErr: true},
+		{name: "invalid query", in: iri.IRI{Query: "#"}, wantErr: true},
+		{name: "invalid fragment", in: iri.IRI{Fragment: "#"}, wantErr: true},
+		{name: "invalid percent-encoding", in: iri.IRI{Path: "%FF"}, wantErr: true},
+		{name: "rootless path with authority", in: iri.IRI{Authority: "example.com", Path: "path"}, wantErr: true},
+		{name: "double slash path without authority", in: iri.IRI{Scheme: "https", Path: "//path"}, wantErr: true},
+		{name: "colon in first segment without scheme", in: iri.IRI{Path: "a:b/c"}, wantErr: true},
+		{name: "colon in later segment without scheme", in: iri.IRI{Path: "a/b:c"}},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.in.Validate()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSafeString(t *testing.T) {
+	tt := []struct {
+		name    string
+		in      iri.IRI
+		want    string
+		wantErr bool
+	}{
+		{name: "space in path with authority", in: iri.IRI{Scheme: "https", Authority: "example.com", Path: "/a b"}, wantErr: true},
+		{name: "space in path", in: iri.IRI{Path: "/ "}, wantErr: true},
+		{name: "forced empty components", in: iri.IRI{ForceAuthority: true, ForceQuery: true, ForceFragment: true}, want: "//?#"},
+		{name: "common", in: iri.IRI{Scheme: "https", Authority: "example.com", Path: "/µ", Query: "q=1", Fragment: "f"}, want: "https://example.com/µ?q=1#f"},
+		{name: "redundant force flag", in: iri.IRI{ForceQuery: true, Query: "q"}, want: "?q"},
+	}
+	t.Parallel()
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tc.in.SafeString()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got err %v, wantErr = %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("SafeString() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSafeStringRejectsWhatStringEmits(t *testing.T) {
+	t.Parallel()
+	value := iri.IRI{Path: "/ "}
+	if got := value.String(); got != "/ " {
+		t.Errorf("String() = %q, want %q", got, "/ ")
+	}
+	if _, err := value.SafeString(); err == nil {
+		t.Errorf("SafeString() did not return an error")
+	}
+}
@@ -0,0 +1,76 @@
+package iri
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WarningCode identifies the kind of a Warning. The codes are stable and can be used
+// to filter warnings, or to suppress them selectively.
+type WarningCode string
+
+// These are the codes of warnings reported by ParseWithWarnings.
+const (
+	// WarningUserInfo is reported if the authority contains userinfo.
+	WarningUserInfo WarningCode = "userinfo"
+	// WarningUserInfoPassword is reported if the userinfo has the deprecated "user:password" format.
+	WarningUserInfoPassword WarningCode = "userinfo-password"
+	// WarningMixedScriptHost is reported if the host mixes characters of several scripts.
+	// See IRI.HostConfusableScripts.
+	WarningMixedScriptHost WarningCode = "mixed-script-host"
+	// WarningPercentEncoding is reported if the percent-encoding is not normalized,
+	// such as for encoded unreserved characters. See NormalizePercentEncoding.
+	WarningPercentEncoding WarningCode = "percent-encoding"
+	// WarningBidi is reported if a component violates the rules for bidirectional IRIs.
+	// See IRI.BidiIssues.
+	WarningBidi WarningCode = "bidi"
+)
+
+// Warning is an advisory about a valid, yet suspicious IRI.
+type Warning struct {
+	// Code identifies the kind of the warning.
+	Code WarningCode
+	// Message describes the warning in detail.
+	Message string
+}
+
+// String returns the code and the message of the warning.
+func (warning Warning) String() string {
+	return fmt.Sprintf("%s: %s", warning.Code, warning.Message)
+}
+
+// ParseWithWarnings parses a string like Parse, and additionally returns warnings about
+// the parsed IRI. Warnings do not cause an error; They are meant to be surfaced,
+// such as by linters. Warnings are reported in the order of their codes as listed above.
+//
+// If parsing fails, no warnings are returned.
+func ParseWithWarnings(s string, opts ...ParseOption) (IRI, []Warning, error) {
+	parsed, err := Parse(s, opts...)
+	if err != nil {
+		return IRI{}, nil, err
+	}
+	return parsed, parsed.warnings(), nil
+}
+
+func (iri IRI) warnings() []Warning {
+	var warnings []Warning
+	if parts := splitAuthority(iri.Authority); parts.hasUserInfo {
+		warnings = append(warnings, Warning{Code: WarningUserInfo, Message: "authority contains userinfo"})
+		if strings.Contains(parts.userInfo, ":") {
+			warnings = append(warnings, Warning{Code: WarningUserInfoPassword, Message: "userinfo contains a password"})
+		}
+	}
+	if scripts, mixed := iri.HostConfusableScripts(); mixed {
+		warnings = append(warnings, Warning{
+			Code:    WarningMixedScriptHost,
+			Message: fmt.Sprintf("host mixes scripts %s", strings.Join(scripts, ", ")),
+		})
+	}
+	if normalized, err := NormalizePercentEncoding(iri); (err == nil) && (normalized != iri) {
+		warnings = append(warnings, Warning{Code: WarningPercentEncoding, Message: fmt.Sprintf("percent-encoding is not normalized, expected %q", normalized)})
+	}
+	for _, issue := range iri.BidiIssues() {
+		warnings = append(warnings, Warning{Code: WarningBidi, Message: issue.String()})
+	}
+	return warnings
+}